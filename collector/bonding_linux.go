@@ -28,8 +28,8 @@ import (
 )
 
 type bondingCollector struct {
-	slaves, active typedDesc
-	logger         *slog.Logger
+	slaves, active, slaveLink typedDesc
+	logger                    *slog.Logger
 }
 
 func init() {
@@ -37,7 +37,8 @@ func init() {
 }
 
 // NewBondingCollector returns a newly allocated bondingCollector.
-// It exposes the number of configured and active slave of linux bonding interfaces.
+// It exposes the number of configured and active slave of linux bonding interfaces
+// and the link state of each slave.
 func NewBondingCollector(logger *slog.Logger) (Collector, error) {
 	return &bondingCollector{
 		slaves: typedDesc{prometheus.NewDesc(
@@ -50,6 +51,11 @@ func NewBondingCollector(logger *slog.Logger) (Collector, error) {
 			"Number of active slaves per bonding interface.",
 			[]string{"master"}, nil,
 		), prometheus.GaugeValue},
+		slaveLink: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "bonding", "slave_link"),
+			"Whether the MII status of the bonding slave is up.",
+			[]string{"master", "slave"}, nil,
+		), prometheus.GaugeValue},
 		logger: logger,
 	}, nil
 }
@@ -65,38 +71,54 @@ func (c *bondingCollector) Update(ch chan<- prometheus.Metric) error {
 		}
 		return err
 	}
-	for master, status := range bondingStats {
-		ch <- c.slaves.mustNewConstMetric(float64(status[0]), master)
-		ch <- c.active.mustNewConstMetric(float64(status[1]), master)
+	for master, slaves := range bondingStats {
+		active := 0
+		for slave, up := range slaves {
+			link := 0.0
+			if up {
+				active++
+				link = 1
+			}
+			ch <- c.slaveLink.mustNewConstMetric(link, master, slave)
+		}
+		ch <- c.slaves.mustNewConstMetric(float64(len(slaves)), master)
+		ch <- c.active.mustNewConstMetric(float64(active), master)
 	}
 	return nil
 }
 
-func readBondingStats(root string) (status map[string][2]int, err error) {
-	status = map[string][2]int{}
+// readBondingStats returns whether the MII status is up for each slave of
+// each bonding master.
+func readBondingStats(root string) (status map[string]map[string]bool, err error) {
+	status = map[string]map[string]bool{}
 	masters, err := os.ReadFile(filepath.Join(root, "bonding_masters"))
 	if err != nil {
 		return nil, err
 	}
 	for _, master := range strings.Fields(string(masters)) {
 		slaves, err := os.ReadFile(filepath.Join(root, master, "bonding", "slaves"))
+		if errors.Is(err, os.ErrNotExist) {
+			// The master was removed after reading bonding_masters.
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
-		sstat := [2]int{0, 0}
+		sstat := map[string]bool{}
 		for _, slave := range strings.Fields(string(slaves)) {
 			state, err := os.ReadFile(filepath.Join(root, master, fmt.Sprintf("lower_%s", slave), "bonding_slave", "mii_status"))
 			if errors.Is(err, os.ErrNotExist) {
 				// some older? kernels use slave_ prefix
 				state, err = os.ReadFile(filepath.Join(root, master, fmt.Sprintf("slave_%s", slave), "bonding_slave", "mii_status"))
 			}
+			if errors.Is(err, os.ErrNotExist) {
+				// The slave was released after reading the list of slaves.
+				continue
+			}
 			if err != nil {
 				return nil, err
 			}
-			sstat[0]++
-			if strings.TrimSpace(string(state)) == "up" {
-				sstat[1]++
-			}
+			sstat[slave] = strings.TrimSpace(string(state)) == "up"
 		}
 		status[master] = sstat
 	}
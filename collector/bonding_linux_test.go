@@ -17,6 +17,8 @@
 package collector
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -25,15 +27,49 @@ func TestBonding(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if bondingStats["bond0"][0] != 0 || bondingStats["bond0"][1] != 0 {
+	if len(bondingStats["bond0"]) != 0 {
 		t.Fatal("bond0 in unexpected state")
 	}
 
-	if bondingStats["int"][0] != 2 || bondingStats["int"][1] != 1 {
+	if len(bondingStats["int"]) != 2 || !bondingStats["int"]["eth5"] || bondingStats["int"]["eth1"] {
 		t.Fatal("int in unexpected state")
 	}
 
-	if bondingStats["dmz"][0] != 2 || bondingStats["dmz"][1] != 2 {
+	if len(bondingStats["dmz"]) != 2 || !bondingStats["dmz"]["eth0"] || !bondingStats["dmz"]["eth4"] {
 		t.Fatal("dmz in unexpected state")
 	}
 }
+
+func TestBondingChangedDuringRead(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"bonding_masters":                           "bond0 bond1 bond2\n",
+		"bond0/bonding/slaves":                      "eth0 eth1\n",
+		"bond0/lower_eth0/bonding_slave/mii_status": "up\n",
+		"bond1/bonding/slaves":                      "\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// eth1 was released from bond0 and bond2 was removed after listing them.
+	bondingStats, err := readBondingStats(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bondingStats) != 2 {
+		t.Fatalf("want 2 masters, got %v", bondingStats)
+	}
+	if len(bondingStats["bond0"]) != 1 || !bondingStats["bond0"]["eth0"] {
+		t.Fatalf("bond0 in unexpected state: %v", bondingStats["bond0"])
+	}
+	if len(bondingStats["bond1"]) != 0 {
+		t.Fatalf("bond1 in unexpected state: %v", bondingStats["bond1"])
+	}
+}
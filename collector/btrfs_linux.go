@@ -19,7 +19,10 @@ package collector
 import (
 	"fmt"
 	"log/slog"
+	"os"
 	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 
@@ -313,11 +316,11 @@ func (c *btrfsCollector) getMetrics(s *btrfs.Stats, ioctlStats *btrfsIoctlFsStat
 				desc:            "Size of a device that is part of the filesystem.",
 				metricType:      prometheus.GaugeValue,
 				value:           float64(dev.Size),
-				extraLabel:      []string{"device"},
-				extraLabelValue: []string{n},
+				extraLabel:      []string{"device", "btrfs_dev_uuid"},
+				extraLabelValue: []string{n, ""},
 			})
 		}
-		return metrics
+		return append(metrics, c.getSysfsDeviceErrors(s)...)
 	}
 
 	for _, dev := range ioctlStats.devices {
@@ -383,6 +386,61 @@ func (c *btrfsCollector) getMetrics(s *btrfs.Stats, ioctlStats *btrfsIoctlFsStat
 	return metrics
 }
 
+// getSysfsDeviceErrors returns the error counters of the devices of the
+// filesystem from sysfs, for when they can't be queried with ioctl, e.g. when
+// not running as root. They have the same labels as the ioctl ones, but sysfs
+// only identifies the devices by their ID and doesn't have their UUID. Unless
+// the filesystem has a single device, the device label is set to
+// "devid:<id>", as btrfs-progs does for devices without path.
+func (c *btrfsCollector) getSysfsDeviceErrors(s *btrfs.Stats) []btrfsMetric {
+	devinfo := sysFilePath(filepath.Join("fs/btrfs", s.UUID, "devinfo"))
+	devices, err := os.ReadDir(devinfo)
+	if err != nil {
+		// devinfo is only available since Linux 5.9, error_stats since 5.14.
+		return nil
+	}
+
+	var singleDevice string
+	if len(s.Devices) == 1 && len(devices) == 1 {
+		for name := range s.Devices {
+			singleDevice = name
+		}
+	}
+
+	var metrics []btrfsMetric
+	for _, device := range devices {
+		content, err := os.ReadFile(filepath.Join(devinfo, device.Name(), "error_stats"))
+		if err != nil {
+			continue
+		}
+		deviceName := singleDevice
+		if deviceName == "" {
+			deviceName = "devid:" + device.Name()
+		}
+		// Lines look like "write_errs 0".
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+			key, value, ok := strings.Cut(line, " ")
+			errorType, isErrs := strings.CutSuffix(key, "_errs")
+			if !ok || !isErrs {
+				continue
+			}
+			v, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				continue
+			}
+			metrics = append(metrics, btrfsMetric{
+				name:            "device_errors_total",
+				desc:            "Errors reported for the device",
+				metricType:      prometheus.CounterValue,
+				value:           float64(v),
+				extraLabel:      []string{"type", "device", "btrfs_dev_uuid"},
+				extraLabelValue: []string{errorType, deviceName, ""},
+			})
+		}
+	}
+	return metrics
+}
+
 // getAllocationStats returns allocation metrics for the given Btrfs Allocation statistics.
 func (c *btrfsCollector) getAllocationStats(a string, s *btrfs.AllocationStats) []btrfsMetric {
 	metrics := []btrfsMetric{
@@ -17,9 +17,13 @@
 package collector
 
 import (
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/procfs/btrfs"
 )
 
@@ -43,8 +47,18 @@ var expectedBtrfsMetrics = [][]btrfsMetric{
 		{name: "used_bytes", value: 16384, extraLabel: []string{"block_group_type", "mode"}, extraLabelValue: []string{"system", "raid1"}},
 		{name: "size_bytes", value: 8.388608e+06, extraLabel: []string{"block_group_type", "mode"}, extraLabelValue: []string{"system", "raid1"}},
 		{name: "allocation_ratio", value: 2, extraLabel: []string{"block_group_type", "mode"}, extraLabelValue: []string{"system", "raid1"}},
-		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device"}, extraLabelValue: []string{"loop25"}},
-		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device"}, extraLabelValue: []string{"loop26"}},
+		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device", "btrfs_dev_uuid"}, extraLabelValue: []string{"loop25", ""}},
+		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device", "btrfs_dev_uuid"}, extraLabelValue: []string{"loop26", ""}},
+		{name: "device_errors_total", value: 0, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"write", "devid:1", ""}},
+		{name: "device_errors_total", value: 0, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"read", "devid:1", ""}},
+		{name: "device_errors_total", value: 0, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"flush", "devid:1", ""}},
+		{name: "device_errors_total", value: 0, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"corruption", "devid:1", ""}},
+		{name: "device_errors_total", value: 0, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"generation", "devid:1", ""}},
+		{name: "device_errors_total", value: 0, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"write", "devid:2", ""}},
+		{name: "device_errors_total", value: 3, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"read", "devid:2", ""}},
+		{name: "device_errors_total", value: 0, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"flush", "devid:2", ""}},
+		{name: "device_errors_total", value: 1, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"corruption", "devid:2", ""}},
+		{name: "device_errors_total", value: 0, metricType: 1, extraLabel: []string{"type", "device", "btrfs_dev_uuid"}, extraLabelValue: []string{"generation", "devid:2", ""}},
 	},
 	{
 		{name: "info", value: 1, extraLabel: []string{"label"}, extraLabelValue: []string{""}},
@@ -65,10 +79,10 @@ var expectedBtrfsMetrics = [][]btrfsMetric{
 		{name: "used_bytes", value: 16384, extraLabel: []string{"block_group_type", "mode"}, extraLabelValue: []string{"system", "raid6"}},
 		{name: "size_bytes", value: 1.6777216e+07, extraLabel: []string{"block_group_type", "mode"}, extraLabelValue: []string{"system", "raid6"}},
 		{name: "allocation_ratio", value: 2, extraLabel: []string{"block_group_type", "mode"}, extraLabelValue: []string{"system", "raid6"}},
-		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device"}, extraLabelValue: []string{"loop22"}},
-		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device"}, extraLabelValue: []string{"loop23"}},
-		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device"}, extraLabelValue: []string{"loop24"}},
-		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device"}, extraLabelValue: []string{"loop25"}},
+		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device", "btrfs_dev_uuid"}, extraLabelValue: []string{"loop22", ""}},
+		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device", "btrfs_dev_uuid"}, extraLabelValue: []string{"loop23", ""}},
+		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device", "btrfs_dev_uuid"}, extraLabelValue: []string{"loop24", ""}},
+		{name: "device_size_bytes", value: 1.073741824e+10, extraLabel: []string{"device", "btrfs_dev_uuid"}, extraLabelValue: []string{"loop25", ""}},
 	},
 }
 
@@ -100,6 +114,9 @@ func checkMetric(exp, got *btrfsMetric) bool {
 }
 
 func TestBtrfs(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
 	fs, err := btrfs.NewFS("fixtures/sys")
 	if err != nil {
 		t.Fatal(err)
@@ -128,3 +145,65 @@ func TestBtrfs(t *testing.T) {
 		}
 	}
 }
+
+type testBtrfsCollector struct {
+	c     *btrfsCollector
+	stats []*btrfs.Stats
+	ioctl []*btrfsIoctlFsStats
+}
+
+func (c testBtrfsCollector) Collect(ch chan<- prometheus.Metric) {
+	for i, s := range c.stats {
+		c.c.updateBtrfsStats(ch, s, c.ioctl[i])
+	}
+}
+
+func (c testBtrfsCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestBtrfsDeviceErrorsSysfsAndIoctl(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	fs, err := btrfs.NewFS("fixtures/sys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := fs.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].UUID < stats[j].UUID })
+
+	// The first filesystem falls back to sysfs, the second one was
+	// queried with ioctl.
+	ioctl := []*btrfsIoctlFsStats{nil, {
+		uuid: stats[1].UUID,
+		devices: []btrfsIoctlFsDevStats{
+			{path: "/dev/loop22", uuid: "d1b9ef0c-5a5e-4b6e-9c5c-2a0a9b8f5c11", totalBytes: 10, bytesUsed: 4, readErrs: 2},
+		},
+	}}
+	want := `# HELP node_btrfs_device_errors_total Errors reported for the device
+		# TYPE node_btrfs_device_errors_total counter
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:1",type="corruption",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:1",type="flush",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:1",type="generation",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:1",type="read",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:1",type="write",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:2",type="corruption",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 1
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:2",type="flush",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:2",type="generation",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:2",type="read",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 3
+		node_btrfs_device_errors_total{btrfs_dev_uuid="",device="devid:2",type="write",uuid="0abb23a9-579b-43e6-ad30-227ef47fcb9d"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="d1b9ef0c-5a5e-4b6e-9c5c-2a0a9b8f5c11",device="loop22",type="corruption",uuid="7f07c59f-6136-449c-ab87-e1cf2328731b"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="d1b9ef0c-5a5e-4b6e-9c5c-2a0a9b8f5c11",device="loop22",type="flush",uuid="7f07c59f-6136-449c-ab87-e1cf2328731b"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="d1b9ef0c-5a5e-4b6e-9c5c-2a0a9b8f5c11",device="loop22",type="generation",uuid="7f07c59f-6136-449c-ab87-e1cf2328731b"} 0
+		node_btrfs_device_errors_total{btrfs_dev_uuid="d1b9ef0c-5a5e-4b6e-9c5c-2a0a9b8f5c11",device="loop22",type="read",uuid="7f07c59f-6136-449c-ab87-e1cf2328731b"} 2
+		node_btrfs_device_errors_total{btrfs_dev_uuid="d1b9ef0c-5a5e-4b6e-9c5c-2a0a9b8f5c11",device="loop22",type="write",uuid="7f07c59f-6136-449c-ab87-e1cf2328731b"} 0
+`
+	c := testBtrfsCollector{c: &btrfsCollector{fs: fs}, stats: stats, ioctl: ioctl}
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want), "node_btrfs_device_errors_total"); err != nil {
+		t.Fatal(err)
+	}
+}
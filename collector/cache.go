@@ -0,0 +1,113 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheJitter = kingpin.Flag("collector.cache-jitter", "Expire the first cached result of each collector with a cache TTL after a random fraction of the TTL, so that cached collectors don't all refresh on the same scrape.").Bool()
+
+	// collectorCacheTTLs holds how long the metrics of a collector are
+	// served from cache.
+	collectorCacheTTLs = make(map[string]time.Duration)
+)
+
+// SetCollectorCacheTTL makes the named collector serve the metrics of a
+// successful run for ttl instead of running on every scrape.
+func SetCollectorCacheTTL(collector string, ttl time.Duration) error {
+	if _, exist := collectorState[collector]; !exist {
+		return fmt.Errorf("missing collector: %s", collector)
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("cache TTL of collector %s must be positive, got %s", collector, ttl)
+	}
+	collectorCacheTTLs[collector] = ttl
+	return nil
+}
+
+// cachedCollector replays the metrics of the last successful Update of the
+// wrapped collector until they expire.
+type cachedCollector struct {
+	collector Collector
+	ttl       time.Duration
+	// jitter returns the fraction of ttl after which the first result
+	// expires.
+	jitter func() float64
+	now    func() time.Time
+
+	mtx     sync.Mutex
+	metrics []prometheus.Metric
+	expires time.Time
+}
+
+func newCachedCollector(c Collector, ttl time.Duration, jitter bool) *cachedCollector {
+	cc := &cachedCollector{
+		collector: c,
+		ttl:       ttl,
+		jitter:    func() float64 { return 1 },
+		now:       time.Now,
+	}
+	if jitter {
+		// Float64 is in [0, 1), don't expire the first result right away.
+		cc.jitter = func() float64 { return 1 - rand.Float64() }
+	}
+	return cc
+}
+
+func (c *cachedCollector) Update(ch chan<- prometheus.Metric) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.now()
+	if c.metrics != nil && now.Before(c.expires) {
+		for _, m := range c.metrics {
+			ch <- m
+		}
+		return nil
+	}
+
+	metrics := []prometheus.Metric{}
+	forward := make(chan prometheus.Metric)
+	done := make(chan struct{})
+	go func() {
+		for m := range forward {
+			metrics = append(metrics, m)
+			ch <- m
+		}
+		close(done)
+	}()
+	err := c.collector.Update(forward)
+	close(forward)
+	<-done
+
+	// Failures are retried on the next scrape.
+	if err != nil {
+		c.metrics = nil
+		return err
+	}
+	ttl := c.ttl
+	if c.expires.IsZero() {
+		ttl = time.Duration(float64(ttl) * c.jitter())
+	}
+	c.metrics, c.expires = metrics, now.Add(ttl)
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// countingCollector exports the number of times it was updated.
+type countingCollector struct {
+	updates int
+	err     error
+	desc    *prometheus.Desc
+}
+
+func (c *countingCollector) Update(ch chan<- prometheus.Metric) error {
+	c.updates++
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, float64(c.updates))
+	return c.err
+}
+
+func TestCachedCollector(t *testing.T) {
+	inner := &countingCollector{desc: prometheus.NewDesc("node_test_updates_total", "Updates.", nil, nil)}
+	c := newCachedCollector(inner, time.Minute, false)
+	c.jitter = func() float64 { return 0.5 }
+	now := time.Unix(1700000000, 0)
+	c.now = func() time.Time { return now }
+
+	for _, step := range []struct {
+		after   time.Duration
+		err     error
+		updates int
+	}{
+		{0, nil, 1},
+		// The first result expires after half the TTL.
+		{29 * time.Second, nil, 1},
+		{time.Second, nil, 2},
+		{59 * time.Second, nil, 2},
+		// Failures aren't cached.
+		{time.Second, errors.New("boom"), 3},
+		{time.Second, errors.New("boom"), 4},
+		{time.Second, nil, 5},
+		{time.Second, nil, 5},
+	} {
+		now = now.Add(step.after)
+		inner.err = step.err
+
+		ch := make(chan prometheus.Metric, 10)
+		err := c.Update(ch)
+		close(ch)
+		if !errors.Is(err, step.err) {
+			t.Fatalf("after %s: want error %v, got %v", step.after, step.err, err)
+		}
+		if inner.updates != step.updates {
+			t.Fatalf("after %s: want %d updates, got %d", step.after, step.updates, inner.updates)
+		}
+		if len(ch) != 1 {
+			t.Fatalf("after %s: want 1 metric, got %d", step.after, len(ch))
+		}
+	}
+}
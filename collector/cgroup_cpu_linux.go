@@ -0,0 +1,167 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nocgroup_cpu
+// +build !nocgroup_cpu
+
+package collector
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const cgroupCPUSubsystem = "cgroup_cpu"
+
+var (
+	cgroupCPUCgroups = kingpin.Flag("collector.cgroup_cpu.cgroups", "cgroup path relative to the cgroup hierarchy to read cpu.stat from, e.g. system.slice. (repeatable)").Strings()
+
+	// cgroupV1CPUControllers are the mount points of the cgroup v1 cpu
+	// controller, which is usually co-mounted with cpuacct.
+	cgroupV1CPUControllers = []string{"cpu,cpuacct", "cpuacct", "cpu"}
+)
+
+type cgroupCPUCollector struct {
+	cgroups          []string
+	throttledPeriods *prometheus.Desc
+	throttledSeconds *prometheus.Desc
+	logger           *slog.Logger
+}
+
+func init() {
+	registerCollector(cgroupCPUSubsystem, defaultDisabled, NewCgroupCPUCollector)
+}
+
+// NewCgroupCPUCollector returns a new Collector exposing CPU throttling
+// counters of cgroups.
+func NewCgroupCPUCollector(logger *slog.Logger) (Collector, error) {
+	// The cgroups are relative to the hierarchy, so surrounding slashes don't
+	// matter and "foo" and "/foo/" name the same cgroup.
+	cgroups := make([]string, 0, len(*cgroupCPUCgroups))
+	seen := map[string]struct{}{}
+	for _, cgroup := range *cgroupCPUCgroups {
+		cgroup = strings.Trim(cgroup, "/")
+		if _, ok := seen[cgroup]; ok {
+			return nil, fmt.Errorf("duplicate cgroup %q in --collector.cgroup_cpu.cgroups", cgroup)
+		}
+		seen[cgroup] = struct{}{}
+		cgroups = append(cgroups, cgroup)
+	}
+
+	labels := []string{"cgroup"}
+	return &cgroupCPUCollector{
+		cgroups: cgroups,
+		throttledPeriods: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, cgroupCPUSubsystem, "throttled_periods_total"),
+			"Number of enforcement periods in which the cgroup was throttled.",
+			labels, nil,
+		),
+		throttledSeconds: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, cgroupCPUSubsystem, "throttled_seconds_total"),
+			"Total time the cgroup was throttled for in seconds.",
+			labels, nil,
+		),
+		logger: logger,
+	}, nil
+}
+
+func (c *cgroupCPUCollector) Update(ch chan<- prometheus.Metric) error {
+	if len(c.cgroups) == 0 {
+		return ErrNoData
+	}
+
+	// The unified hierarchy has cgroup.controllers in its root, cgroup v1
+	// mounts one hierarchy per controller instead.
+	unified := true
+	if _, err := os.Stat(sysFilePath("fs/cgroup/cgroup.controllers")); err != nil {
+		unified = false
+	}
+
+	for _, cgroup := range c.cgroups {
+		stats, err := readCgroupCPUStat(cgroup, unified)
+		if err != nil {
+			if os.IsNotExist(err) {
+				c.logger.Debug("cgroup has no cpu.stat", "cgroup", cgroup)
+				continue
+			}
+			return fmt.Errorf("couldn't get cpu.stat for cgroup %q: %w", cgroup, err)
+		}
+
+		// The throttling fields are only present with the cpu controller
+		// enabled for the cgroup.
+		if v, ok := stats["nr_throttled"]; ok {
+			ch <- prometheus.MustNewConstMetric(c.throttledPeriods, prometheus.CounterValue, float64(v), cgroup)
+		}
+		if v, ok := stats["throttled_usec"]; ok {
+			ch <- prometheus.MustNewConstMetric(c.throttledSeconds, prometheus.CounterValue, float64(v)/1e6, cgroup)
+		} else if v, ok := stats["throttled_time"]; ok {
+			// cgroup v1 reports nanoseconds.
+			ch <- prometheus.MustNewConstMetric(c.throttledSeconds, prometheus.CounterValue, float64(v)/1e9, cgroup)
+		}
+	}
+	return nil
+}
+
+// readCgroupCPUStat reads cpu.stat of the cgroup from the unified hierarchy
+// or from the first cgroup v1 cpu controller hierarchy that has it.
+func readCgroupCPUStat(cgroup string, unified bool) (map[string]uint64, error) {
+	if unified {
+		return parseCgroupCPUStat(sysFilePath(filepath.Join("fs/cgroup", cgroup, "cpu.stat")))
+	}
+
+	var err error
+	for _, controller := range cgroupV1CPUControllers {
+		var stats map[string]uint64
+		stats, err = parseCgroupCPUStat(sysFilePath(filepath.Join("fs/cgroup", controller, cgroup, "cpu.stat")))
+		if !os.IsNotExist(err) {
+			return stats, err
+		}
+	}
+	return nil, err
+}
+
+// parseCgroupCPUStat parses a cpu.stat file, which contains one
+// "<key> <value>" pair per line.
+func parseCgroupCPUStat(path string) (map[string]uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := map[string]uint64{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid cpu.stat line %q", scanner.Text())
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpu.stat value %q: %w", scanner.Text(), err)
+		}
+		stats[fields[0]] = v
+	}
+	return stats, scanner.Err()
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nocgroup_cpu
+// +build !nocgroup_cpu
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testCgroupCPUCollector struct {
+	cc Collector
+}
+
+func (c testCgroupCPUCollector) Collect(ch chan<- prometheus.Metric) {
+	c.cc.Update(ch)
+}
+
+func (c testCgroupCPUCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestCgroupCPU(t *testing.T) {
+	v1 := t.TempDir()
+	dir := filepath.Join(v1, "fs/cgroup/cpu,cpuacct/docker/abc")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cpu.stat"), []byte("nr_periods 120\nnr_throttled 15\nthrottled_time 2500000000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name    string
+		sysfs   string
+		cgroups []string
+		want    string
+	}{
+		{
+			name:  "cgroup v2",
+			sysfs: "fixtures/sys",
+			// user.slice doesn't have the cpu controller enabled.
+			cgroups: []string{"system.slice", "/user.slice/", "missing.slice"},
+			want: `# HELP node_cgroup_cpu_throttled_periods_total Number of enforcement periods in which the cgroup was throttled.
+				# TYPE node_cgroup_cpu_throttled_periods_total counter
+				node_cgroup_cpu_throttled_periods_total{cgroup="system.slice"} 734
+				# HELP node_cgroup_cpu_throttled_seconds_total Total time the cgroup was throttled for in seconds.
+				# TYPE node_cgroup_cpu_throttled_seconds_total counter
+				node_cgroup_cpu_throttled_seconds_total{cgroup="system.slice"} 12.467214
+`,
+		},
+		{
+			name:    "cgroup v1",
+			sysfs:   v1,
+			cgroups: []string{"docker/abc", "missing"},
+			want: `# HELP node_cgroup_cpu_throttled_periods_total Number of enforcement periods in which the cgroup was throttled.
+				# TYPE node_cgroup_cpu_throttled_periods_total counter
+				node_cgroup_cpu_throttled_periods_total{cgroup="docker/abc"} 15
+				# HELP node_cgroup_cpu_throttled_seconds_total Total time the cgroup was throttled for in seconds.
+				# TYPE node_cgroup_cpu_throttled_seconds_total counter
+				node_cgroup_cpu_throttled_seconds_total{cgroup="docker/abc"} 2.5
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			args := []string{"--path.sysfs", test.sysfs}
+			for _, cgroup := range test.cgroups {
+				args = append(args, "--collector.cgroup_cpu.cgroups", cgroup)
+			}
+			if _, err := kingpin.CommandLine.Parse(args); err != nil {
+				t.Fatal(err)
+			}
+			defer func() { *cgroupCPUCgroups = nil }()
+
+			cc, err := NewCgroupCPUCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutil.CollectAndCompare(testCgroupCPUCollector{cc}, strings.NewReader(test.want)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestCgroupCPUDuplicateCgroup(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--collector.cgroup_cpu.cgroups", "system.slice",
+		"--collector.cgroup_cpu.cgroups", "/system.slice/",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *cgroupCPUCgroups = nil }()
+
+	if _, err := NewCgroupCPUCollector(slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("want error for duplicate cgroup")
+	}
+}
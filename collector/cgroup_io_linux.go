@@ -0,0 +1,159 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nocgroup_io
+// +build !nocgroup_io
+
+package collector
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs/blockdevice"
+)
+
+const cgroupIOSubsystem = "cgroup_io"
+
+var (
+	cgroupIOCgroups = kingpin.Flag("collector.cgroup_io.cgroups", "cgroup v2 path relative to /sys/fs/cgroup to read io.stat from, e.g. system.slice. (repeatable)").Strings()
+)
+
+type cgroupIOCollector struct {
+	fs      blockdevice.FS
+	cgroups []string
+	rbytes  *prometheus.Desc
+	wbytes  *prometheus.Desc
+	logger  *slog.Logger
+}
+
+// cgroupIOStat holds the counters of a single io.stat line.
+type cgroupIOStat struct {
+	device string
+	rbytes uint64
+	wbytes uint64
+}
+
+func init() {
+	registerCollector(cgroupIOSubsystem, defaultDisabled, NewCgroupIOCollector)
+}
+
+// NewCgroupIOCollector returns a new Collector exposing per-device IO
+// counters of cgroup v2 groups.
+func NewCgroupIOCollector(logger *slog.Logger) (Collector, error) {
+	fs, err := blockdevice.NewFS(*procPath, *sysPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sysfs: %w", err)
+	}
+
+	labels := []string{"cgroup", "device"}
+	return &cgroupIOCollector{
+		fs:      fs,
+		cgroups: *cgroupIOCgroups,
+		rbytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, cgroupIOSubsystem, "rbytes_total"),
+			"Number of bytes read from the device by the cgroup.",
+			labels, nil,
+		),
+		wbytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, cgroupIOSubsystem, "wbytes_total"),
+			"Number of bytes written to the device by the cgroup.",
+			labels, nil,
+		),
+		logger: logger,
+	}, nil
+}
+
+func (c *cgroupIOCollector) Update(ch chan<- prometheus.Metric) error {
+	if len(c.cgroups) == 0 {
+		return ErrNoData
+	}
+
+	// io.stat only knows device numbers, resolve them the same way diskstats
+	// names its devices.
+	devices := map[string]string{}
+	diskStats, err := c.fs.ProcDiskstats()
+	if err != nil {
+		c.logger.Debug("couldn't get diskstats, using device numbers", "err", err)
+	}
+	for _, s := range diskStats {
+		devices[fmt.Sprintf("%d:%d", s.MajorNumber, s.MinorNumber)] = s.DeviceName
+	}
+
+	for _, cgroup := range c.cgroups {
+		stats, err := parseCgroupIOStat(sysFilePath(fmt.Sprintf("fs/cgroup/%s/io.stat", strings.Trim(cgroup, "/"))))
+		if err != nil {
+			if os.IsNotExist(err) {
+				c.logger.Debug("cgroup has no io.stat", "cgroup", cgroup)
+				continue
+			}
+			return fmt.Errorf("couldn't get io.stat for cgroup %q: %w", cgroup, err)
+		}
+		// An empty io.stat means IO accounting is not enabled for the cgroup.
+		for _, s := range stats {
+			device, ok := devices[s.device]
+			if !ok {
+				device = s.device
+			}
+			ch <- prometheus.MustNewConstMetric(c.rbytes, prometheus.CounterValue, float64(s.rbytes), cgroup, device)
+			ch <- prometheus.MustNewConstMetric(c.wbytes, prometheus.CounterValue, float64(s.wbytes), cgroup, device)
+		}
+	}
+	return nil
+}
+
+// parseCgroupIOStat parses a cgroup v2 io.stat file, which contains one line
+// per device like "8:0 rbytes=1024 wbytes=0 rios=1 wios=0 dbytes=0 dios=0".
+func parseCgroupIOStat(path string) ([]cgroupIOStat, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []cgroupIOStat
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		s := cgroupIOStat{device: fields[0]}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid io.stat field %q", field)
+			}
+			var dst *uint64
+			switch key {
+			case "rbytes":
+				dst = &s.rbytes
+			case "wbytes":
+				dst = &s.wbytes
+			default:
+				continue
+			}
+			if *dst, err = strconv.ParseUint(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid io.stat value %q: %w", field, err)
+			}
+		}
+		stats = append(stats, s)
+	}
+	return stats, scanner.Err()
+}
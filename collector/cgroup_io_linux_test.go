@@ -0,0 +1,74 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nocgroup_io
+// +build !nocgroup_io
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testCgroupIOCollector struct {
+	cc Collector
+}
+
+func (c testCgroupIOCollector) Collect(ch chan<- prometheus.Metric) {
+	c.cc.Update(ch)
+}
+
+func (c testCgroupIOCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestCgroupIO(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--path.procfs", "fixtures/proc",
+		"--path.sysfs", "fixtures/sys",
+		"--collector.cgroup_io.cgroups", "system.slice",
+		"--collector.cgroup_io.cgroups", "user.slice",
+		"--collector.cgroup_io.cgroups", "missing.slice",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *cgroupIOCgroups = nil }()
+
+	cc, err := NewCgroupIOCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 43:0 is not listed in diskstats and keeps its device number.
+	want := `# HELP node_cgroup_io_rbytes_total Number of bytes read from the device by the cgroup.
+		# TYPE node_cgroup_io_rbytes_total counter
+		node_cgroup_io_rbytes_total{cgroup="system.slice",device="43:0"} 4096
+		node_cgroup_io_rbytes_total{cgroup="system.slice",device="sda"} 1.839104e+06
+		node_cgroup_io_rbytes_total{cgroup="system.slice",device="vda"} 28672
+		# HELP node_cgroup_io_wbytes_total Number of bytes written to the device by the cgroup.
+		# TYPE node_cgroup_io_wbytes_total counter
+		node_cgroup_io_wbytes_total{cgroup="system.slice",device="43:0"} 0
+		node_cgroup_io_wbytes_total{cgroup="system.slice",device="sda"} 1.3975552e+07
+		node_cgroup_io_wbytes_total{cgroup="system.slice",device="vda"} 4096
+`
+	if err := testutil.CollectAndCompare(testCgroupIOCollector{cc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
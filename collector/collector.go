@@ -102,6 +102,39 @@ func collectorFlagAction(collector string) func(ctx *kingpin.ParseContext) error
 	}
 }
 
+// collectorLoggers holds the loggers of collectors whose log level differs
+// from the global one.
+var collectorLoggers = make(map[string]*slog.Logger)
+
+// SetCollectorLogger makes the named collector log through logger instead of
+// the logger passed to NewNodeCollector. A nil logger removes the override.
+func SetCollectorLogger(collector string, logger *slog.Logger) error {
+	if _, exist := collectorState[collector]; !exist {
+		return fmt.Errorf("missing collector: %s", collector)
+	}
+	if logger == nil {
+		delete(collectorLoggers, collector)
+		return nil
+	}
+	collectorLoggers[collector] = logger
+	return nil
+}
+
+// CollectorLoggerOverride returns the logger set with SetCollectorLogger for
+// the named collector, nil if there is none.
+func CollectorLoggerOverride(collector string) *slog.Logger {
+	return collectorLoggers[collector]
+}
+
+// collectorLogger returns the logger of the named collector, logger if its
+// log level isn't overridden.
+func collectorLogger(collector string, logger *slog.Logger) *slog.Logger {
+	if l, ok := collectorLoggers[collector]; ok {
+		return l
+	}
+	return logger
+}
+
 // NewNodeCollector creates a new NodeCollector.
 func NewNodeCollector(logger *slog.Logger, filters ...string) (*NodeCollector, error) {
 	f := make(map[string]bool)
@@ -125,10 +158,13 @@ func NewNodeCollector(logger *slog.Logger, filters ...string) (*NodeCollector, e
 		if collector, ok := initiatedCollectors[key]; ok {
 			collectors[key] = collector
 		} else {
-			collector, err := factories[key](logger.With("collector", key))
+			collector, err := factories[key](collectorLogger(key, logger).With("collector", key))
 			if err != nil {
 				return nil, err
 			}
+			if ttl, ok := collectorCacheTTLs[key]; ok {
+				collector = newCachedCollector(collector, ttl, *cacheJitter)
+			}
 			collectors[key] = collector
 			initiatedCollectors[key] = collector
 		}
@@ -148,7 +184,7 @@ func (n NodeCollector) Collect(ch chan<- prometheus.Metric) {
 	wg.Add(len(n.Collectors))
 	for name, c := range n.Collectors {
 		go func(name string, c Collector) {
-			execute(name, c, ch, n.logger)
+			execute(name, c, ch, collectorLogger(name, n.logger))
 			wg.Done()
 		}(name, c)
 	}
@@ -159,16 +195,29 @@ func execute(name string, c Collector, ch chan<- prometheus.Metric, logger *slog
 	begin := time.Now()
 	err := c.Update(ch)
 	duration := time.Since(begin)
+	recordScrapeResult(name, begin, duration, err)
 	var success float64
 
 	if err != nil {
 		if IsNoDataError(err) {
 			logger.Debug("collector returned no data", "name", name, "duration_seconds", duration.Seconds(), "err", err)
+		} else if ok, repeats, prev := collectorErrorLogs.allow(name, err.Error(), begin); ok {
+			if prev != nil {
+				logger.Error("collector failed", "name", name, "err", prev.msg, "repeated", prev.repeats)
+			}
+			if repeats > 0 {
+				logger.Error("collector failed", "name", name, "duration_seconds", duration.Seconds(), "err", err, "repeated", repeats)
+			} else {
+				logger.Error("collector failed", "name", name, "duration_seconds", duration.Seconds(), "err", err)
+			}
 		} else {
-			logger.Error("collector failed", "name", name, "duration_seconds", duration.Seconds(), "err", err)
+			logger.Debug("collector failed again with the same error", "name", name, "duration_seconds", duration.Seconds(), "err", err)
 		}
 		success = 0
 	} else {
+		if prev := collectorErrorLogs.reset(name); prev != nil {
+			logger.Error("collector failed", "name", name, "err", prev.msg, "repeated", prev.repeats)
+		}
 		logger.Debug("collector succeeded", "name", name, "duration_seconds", duration.Seconds())
 		success = 1
 	}
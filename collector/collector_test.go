@@ -0,0 +1,80 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type createdTestCollector struct {
+	processStart time.Time
+}
+
+var (
+	createdTestCounterDesc = prometheus.NewDesc("test_counter_total", "Counter without created timestamp.", nil, nil)
+	createdTestProcessDesc = prometheus.NewDesc("test_process_total", "Counter with created timestamp.", nil, nil)
+	createdTestGaugeDesc   = prometheus.NewDesc("test_gauge", "Gauge.", nil, nil)
+)
+
+func (c createdTestCollector) Update(ch chan<- prometheus.Metric) error {
+	ch <- prometheus.MustNewConstMetric(createdTestCounterDesc, prometheus.CounterValue, 1)
+	ch <- prometheus.MustNewConstMetricWithCreatedTimestamp(createdTestProcessDesc, prometheus.CounterValue, 1, c.processStart)
+	ch <- prometheus.MustNewConstMetric(createdTestGaugeDesc, prometheus.GaugeValue, 1)
+	return nil
+}
+
+func TestNodeCollectorCreatedTimestamps(t *testing.T) {
+	processStart := time.Unix(1500000000, 0)
+	nc := NodeCollector{
+		Collectors: map[string]Collector{"test": createdTestCollector{processStart: processStart}},
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	r := prometheus.NewRegistry()
+	r.MustRegister(nc)
+	families, err := r.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created := map[string]time.Time{}
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			created[mf.GetName()] = time.Time{}
+			if ts := m.GetCounter().GetCreatedTimestamp(); ts != nil {
+				created[mf.GetName()] = ts.AsTime()
+			}
+		}
+	}
+	for name, want := range map[string]time.Time{
+		// Counters of the kernel existed before the exporter started, so
+		// they don't get a created timestamp made up.
+		"test_counter_total": {},
+		"test_process_total": processStart,
+		"test_gauge":         {},
+	} {
+		got, ok := created[name]
+		if !ok {
+			t.Errorf("%s: missing", name)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("%s: want created timestamp %v, got %v", name, want, got)
+		}
+	}
+}
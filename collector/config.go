@@ -0,0 +1,174 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/alecthomas/kingpin/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// collectorConfig is the content of the file passed to --collector.config.
+//
+//	collectors:
+//	  enable: [systemd, processes]
+//	  disable: [wifi]
+//	options:
+//	  systemd:
+//	    unit-include: "(ssh|cron)\\.service"
+//	  textfile:
+//	    directory: [/var/lib/node_exporter, /run/node_exporter]
+type collectorConfig struct {
+	Collectors struct {
+		Enable  []string `yaml:"enable"`
+		Disable []string `yaml:"disable"`
+	} `yaml:"collectors"`
+	// Options maps a collector name to its flags, named without the
+	// "collector.<name>." prefix.
+	Options map[string]map[string]interface{} `yaml:"options"`
+}
+
+var collectorConfigFile = kingpin.Flag("collector.config", "Path to a YAML file listing collectors to enable or disable and their options. Command-line flags take precedence.").String()
+
+// ExpandCollectorConfig returns args, the raw command line, extended by the
+// flags equivalent to the file passed to --collector.config, if any. Flags
+// present in args take precedence over the file.
+func ExpandCollectorConfig(args []string) ([]string, error) {
+	parseCtx, err := kingpin.CommandLine.ParseContext(args)
+	if err != nil {
+		return nil, err
+	}
+	path := ""
+	explicitFlags := map[string]bool{}
+	for _, el := range parseCtx.Elements {
+		flag, ok := el.Clause.(*kingpin.FlagClause)
+		if !ok {
+			continue
+		}
+		name := flag.Model().Name
+		explicitFlags[name] = true
+		if name == "collector.config" && el.Value != nil {
+			path = *el.Value
+		}
+	}
+	if path == "" {
+		return args, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collector config: %w", err)
+	}
+	cfg, err := parseCollectorConfig(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse collector config %q: %w", path, err)
+	}
+	cfgArgs, err := cfg.args(explicitFlags)
+	if err != nil {
+		return nil, fmt.Errorf("invalid collector config %q: %w", path, err)
+	}
+	return append(cfgArgs, args...), nil
+}
+
+func parseCollectorConfig(content []byte) (*collectorConfig, error) {
+	cfg := &collectorConfig{}
+	if err := yaml.UnmarshalStrict(content, cfg); err != nil {
+		return nil, err
+	}
+
+	for _, name := range append(cfg.Collectors.Enable, cfg.Collectors.Disable...) {
+		if _, ok := collectorState[name]; !ok {
+			return nil, fmt.Errorf("unknown collector: %s", name)
+		}
+	}
+	for _, name := range cfg.Collectors.Enable {
+		for _, disabled := range cfg.Collectors.Disable {
+			if name == disabled {
+				return nil, fmt.Errorf("collector %s is both enabled and disabled", name)
+			}
+		}
+	}
+	for name := range cfg.Options {
+		if _, ok := collectorState[name]; !ok {
+			return nil, fmt.Errorf("unknown collector in options: %s", name)
+		}
+	}
+	return cfg, nil
+}
+
+// args converts the configuration into command-line flags, leaving out every
+// flag which is already set explicitly.
+func (cfg *collectorConfig) args(explicitFlags map[string]bool) ([]string, error) {
+	var args []string
+	for _, name := range cfg.Collectors.Enable {
+		if !explicitFlags["collector."+name] {
+			args = append(args, "--collector."+name)
+		}
+	}
+	for _, name := range cfg.Collectors.Disable {
+		if !explicitFlags["collector."+name] {
+			args = append(args, "--no-collector."+name)
+		}
+	}
+
+	// Emit options in a stable order so the resulting command line is
+	// reproducible.
+	names := make([]string, 0, len(cfg.Options))
+	for name := range cfg.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		options := make([]string, 0, len(cfg.Options[name]))
+		for option := range cfg.Options[name] {
+			options = append(options, option)
+		}
+		sort.Strings(options)
+		for _, option := range options {
+			flagName := fmt.Sprintf("collector.%s.%s", name, option)
+			flag := kingpin.CommandLine.GetFlag(flagName)
+			if flag == nil {
+				return nil, fmt.Errorf("unknown option %q for collector %s", option, name)
+			}
+			if explicitFlags[flagName] {
+				continue
+			}
+			value := cfg.Options[name][option]
+			// Boolean flags don't take a value, they are negated instead.
+			if flag.Model().IsBoolFlag() {
+				enabled, ok := value.(bool)
+				if !ok {
+					return nil, fmt.Errorf("option %q for collector %s must be a boolean", option, name)
+				}
+				if enabled {
+					args = append(args, "--"+flagName)
+				} else {
+					args = append(args, "--no-"+flagName)
+				}
+				continue
+			}
+			values, ok := value.([]interface{})
+			if !ok {
+				values = []interface{}{value}
+			}
+			for _, v := range values {
+				args = append(args, fmt.Sprintf("--%s=%v", flagName, v))
+			}
+		}
+	}
+	return args, nil
+}
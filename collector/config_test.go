@@ -0,0 +1,133 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCollectorConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			config: `
+collectors:
+  enable: [textfile]
+  disable: [loadavg]
+options:
+  textfile:
+    directory: fixtures/textfile/two_metric_files
+`,
+		},
+		{
+			name: "unknown collector",
+			config: `
+collectors:
+  enable: [loadavgg]
+`,
+			wantErr: true,
+		},
+		{
+			name: "unknown collector in options",
+			config: `
+options:
+  loadavgg:
+    foo: bar
+`,
+			wantErr: true,
+		},
+		{
+			name: "enabled and disabled",
+			config: `
+collectors:
+  enable: [loadavg]
+  disable: [loadavg]
+`,
+			wantErr: true,
+		},
+		{
+			name: "unknown key",
+			config: `
+collector:
+  enable: [loadavg]
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseCollectorConfig([]byte(test.config))
+			if test.wantErr != (err != nil) {
+				t.Fatalf("want error %t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCollectorConfigArgs(t *testing.T) {
+	cfg, err := parseCollectorConfig([]byte(`
+collectors:
+  enable: [loadavg, textfile]
+  disable: [meminfo]
+options:
+  textfile:
+    directory: [a, b]
+  filesystem:
+    mount-timeout: 5s
+  netclass:
+    ignore-invalid-speed: true
+    netlink: false
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Flags set on the command line must not be overridden by the file.
+	got, err := cfg.args(map[string]bool{
+		"collector.textfile":                 true,
+		"collector.filesystem.mount-timeout": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"--collector.loadavg",
+		"--no-collector.meminfo",
+		"--collector.netclass.ignore-invalid-speed",
+		"--no-collector.netclass.netlink",
+		"--collector.textfile.directory=a",
+		"--collector.textfile.directory=b",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("want args %q, got %q", want, got)
+	}
+
+	cfg, err = parseCollectorConfig([]byte(`
+options:
+  textfile:
+    no-such-option: x
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cfg.args(map[string]bool{}); err == nil {
+		t.Error("expected error for unknown option")
+	}
+}
@@ -29,14 +29,14 @@ import (
 type conntrackCollector struct {
 	current       *prometheus.Desc
 	limit         *prometheus.Desc
-	found         *prometheus.Desc
-	invalid       *prometheus.Desc
-	ignore        *prometheus.Desc
-	insert        *prometheus.Desc
-	insertFailed  *prometheus.Desc
-	drop          *prometheus.Desc
-	earlyDrop     *prometheus.Desc
-	searchRestart *prometheus.Desc
+	found         conntrackStatDesc
+	invalid       conntrackStatDesc
+	ignore        conntrackStatDesc
+	insert        conntrackStatDesc
+	insertFailed  conntrackStatDesc
+	drop          conntrackStatDesc
+	earlyDrop     conntrackStatDesc
+	searchRestart conntrackStatDesc
 	logger        *slog.Logger
 }
 
@@ -51,6 +51,34 @@ type conntrackStatistics struct {
 	searchRestart uint64 // Number of conntrack table lookups which had to be restarted due to hashtable resizes
 }
 
+// conntrackStatDesc describes one of the per-CPU statistics. They are
+// exported as counters with a _total suffix, and as gauges under their
+// original name for existing dashboards and alerts.
+type conntrackStatDesc struct {
+	gauge   *prometheus.Desc
+	counter *prometheus.Desc
+}
+
+func newConntrackStatDesc(name, help string) conntrackStatDesc {
+	return conntrackStatDesc{
+		gauge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_"+name),
+			help,
+			nil, nil,
+		),
+		counter: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_"+name+"_total"),
+			help,
+			nil, nil,
+		),
+	}
+}
+
+func (d conntrackStatDesc) send(ch chan<- prometheus.Metric, value uint64) {
+	ch <- prometheus.MustNewConstMetric(d.gauge, prometheus.GaugeValue, float64(value))
+	ch <- prometheus.MustNewConstMetric(d.counter, prometheus.CounterValue, float64(value))
+}
+
 func init() {
 	registerCollector("conntrack", defaultEnabled, NewConntrackCollector)
 }
@@ -68,47 +96,15 @@ func NewConntrackCollector(logger *slog.Logger) (Collector, error) {
 			"Maximum size of connection tracking table.",
 			nil, nil,
 		),
-		found: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_found"),
-			"Number of searched entries which were successful.",
-			nil, nil,
-		),
-		invalid: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_invalid"),
-			"Number of packets seen which can not be tracked.",
-			nil, nil,
-		),
-		ignore: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_ignore"),
-			"Number of packets seen which are already connected to a conntrack entry.",
-			nil, nil,
-		),
-		insert: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_insert"),
-			"Number of entries inserted into the list.",
-			nil, nil,
-		),
-		insertFailed: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_insert_failed"),
-			"Number of entries for which list insertion was attempted but failed.",
-			nil, nil,
-		),
-		drop: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_drop"),
-			"Number of packets dropped due to conntrack failure.",
-			nil, nil,
-		),
-		earlyDrop: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_early_drop"),
-			"Number of dropped conntrack entries to make room for new ones, if maximum table size was reached.",
-			nil, nil,
-		),
-		searchRestart: prometheus.NewDesc(
-			prometheus.BuildFQName(namespace, "", "nf_conntrack_stat_search_restart"),
-			"Number of conntrack table lookups which had to be restarted due to hashtable resizes.",
-			nil, nil,
-		),
-		logger: logger,
+		found:         newConntrackStatDesc("found", "Number of searched entries which were successful."),
+		invalid:       newConntrackStatDesc("invalid", "Number of packets seen which can not be tracked."),
+		ignore:        newConntrackStatDesc("ignore", "Number of packets seen which are already connected to a conntrack entry."),
+		insert:        newConntrackStatDesc("insert", "Number of entries inserted into the list."),
+		insertFailed:  newConntrackStatDesc("insert_failed", "Number of entries for which list insertion was attempted but failed."),
+		drop:          newConntrackStatDesc("drop", "Number of packets dropped due to conntrack failure."),
+		earlyDrop:     newConntrackStatDesc("early_drop", "Number of dropped conntrack entries to make room for new ones, if maximum table size was reached."),
+		searchRestart: newConntrackStatDesc("search_restart", "Number of conntrack table lookups which had to be restarted due to hashtable resizes."),
+		logger:        logger,
 	}, nil
 }
 
@@ -132,22 +128,14 @@ func (c *conntrackCollector) Update(ch chan<- prometheus.Metric) error {
 		return c.handleErr(err)
 	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.found, prometheus.GaugeValue, float64(conntrackStats.found))
-	ch <- prometheus.MustNewConstMetric(
-		c.invalid, prometheus.GaugeValue, float64(conntrackStats.invalid))
-	ch <- prometheus.MustNewConstMetric(
-		c.ignore, prometheus.GaugeValue, float64(conntrackStats.ignore))
-	ch <- prometheus.MustNewConstMetric(
-		c.insert, prometheus.GaugeValue, float64(conntrackStats.insert))
-	ch <- prometheus.MustNewConstMetric(
-		c.insertFailed, prometheus.GaugeValue, float64(conntrackStats.insertFailed))
-	ch <- prometheus.MustNewConstMetric(
-		c.drop, prometheus.GaugeValue, float64(conntrackStats.drop))
-	ch <- prometheus.MustNewConstMetric(
-		c.earlyDrop, prometheus.GaugeValue, float64(conntrackStats.earlyDrop))
-	ch <- prometheus.MustNewConstMetric(
-		c.searchRestart, prometheus.GaugeValue, float64(conntrackStats.searchRestart))
+	c.found.send(ch, conntrackStats.found)
+	c.invalid.send(ch, conntrackStats.invalid)
+	c.ignore.send(ch, conntrackStats.ignore)
+	c.insert.send(ch, conntrackStats.insert)
+	c.insertFailed.send(ch, conntrackStats.insertFailed)
+	c.drop.send(ch, conntrackStats.drop)
+	c.earlyDrop.send(ch, conntrackStats.earlyDrop)
+	c.searchRestart.send(ch, conntrackStats.searchRestart)
 	return nil
 }
 
@@ -0,0 +1,131 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noconntrack
+// +build !noconntrack
+
+package collector
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testConntrackCollector struct {
+	cc Collector
+}
+
+func (c testConntrackCollector) Collect(ch chan<- prometheus.Metric) {
+	c.cc.Update(ch)
+}
+
+func (c testConntrackCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestConntrack(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	cc, err := NewConntrackCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The stat counters are summed over the CPUs in /proc/net/stat/nf_conntrack.
+	want := `# HELP node_nf_conntrack_entries Number of currently allocated flow entries for connection tracking.
+		# TYPE node_nf_conntrack_entries gauge
+		node_nf_conntrack_entries 123
+		# HELP node_nf_conntrack_entries_limit Maximum size of connection tracking table.
+		# TYPE node_nf_conntrack_entries_limit gauge
+		node_nf_conntrack_entries_limit 65536
+		# HELP node_nf_conntrack_stat_drop Number of packets dropped due to conntrack failure.
+		# TYPE node_nf_conntrack_stat_drop gauge
+		node_nf_conntrack_stat_drop 0
+		# HELP node_nf_conntrack_stat_drop_total Number of packets dropped due to conntrack failure.
+		# TYPE node_nf_conntrack_stat_drop_total counter
+		node_nf_conntrack_stat_drop_total 0
+		# HELP node_nf_conntrack_stat_early_drop Number of dropped conntrack entries to make room for new ones, if maximum table size was reached.
+		# TYPE node_nf_conntrack_stat_early_drop gauge
+		node_nf_conntrack_stat_early_drop 0
+		# HELP node_nf_conntrack_stat_early_drop_total Number of dropped conntrack entries to make room for new ones, if maximum table size was reached.
+		# TYPE node_nf_conntrack_stat_early_drop_total counter
+		node_nf_conntrack_stat_early_drop_total 0
+		# HELP node_nf_conntrack_stat_found Number of searched entries which were successful.
+		# TYPE node_nf_conntrack_stat_found gauge
+		node_nf_conntrack_stat_found 0
+		# HELP node_nf_conntrack_stat_found_total Number of searched entries which were successful.
+		# TYPE node_nf_conntrack_stat_found_total counter
+		node_nf_conntrack_stat_found_total 0
+		# HELP node_nf_conntrack_stat_ignore Number of packets seen which are already connected to a conntrack entry.
+		# TYPE node_nf_conntrack_stat_ignore gauge
+		node_nf_conntrack_stat_ignore 89738
+		# HELP node_nf_conntrack_stat_ignore_total Number of packets seen which are already connected to a conntrack entry.
+		# TYPE node_nf_conntrack_stat_ignore_total counter
+		node_nf_conntrack_stat_ignore_total 89738
+		# HELP node_nf_conntrack_stat_insert Number of entries inserted into the list.
+		# TYPE node_nf_conntrack_stat_insert gauge
+		node_nf_conntrack_stat_insert 0
+		# HELP node_nf_conntrack_stat_insert_total Number of entries inserted into the list.
+		# TYPE node_nf_conntrack_stat_insert_total counter
+		node_nf_conntrack_stat_insert_total 0
+		# HELP node_nf_conntrack_stat_insert_failed Number of entries for which list insertion was attempted but failed.
+		# TYPE node_nf_conntrack_stat_insert_failed gauge
+		node_nf_conntrack_stat_insert_failed 0
+		# HELP node_nf_conntrack_stat_insert_failed_total Number of entries for which list insertion was attempted but failed.
+		# TYPE node_nf_conntrack_stat_insert_failed_total counter
+		node_nf_conntrack_stat_insert_failed_total 0
+		# HELP node_nf_conntrack_stat_invalid Number of packets seen which can not be tracked.
+		# TYPE node_nf_conntrack_stat_invalid gauge
+		node_nf_conntrack_stat_invalid 53
+		# HELP node_nf_conntrack_stat_invalid_total Number of packets seen which can not be tracked.
+		# TYPE node_nf_conntrack_stat_invalid_total counter
+		node_nf_conntrack_stat_invalid_total 53
+		# HELP node_nf_conntrack_stat_search_restart Number of conntrack table lookups which had to be restarted due to hashtable resizes.
+		# TYPE node_nf_conntrack_stat_search_restart gauge
+		node_nf_conntrack_stat_search_restart 7
+		# HELP node_nf_conntrack_stat_search_restart_total Number of conntrack table lookups which had to be restarted due to hashtable resizes.
+		# TYPE node_nf_conntrack_stat_search_restart_total counter
+		node_nf_conntrack_stat_search_restart_total 7
+`
+	if err := testutil.CollectAndCompare(testConntrackCollector{cc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConntrackNotLoaded(t *testing.T) {
+	// fixtures_hidepid has no /proc/sys/net/netfilter, like hosts without
+	// the nf_conntrack module.
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures_hidepid/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	cc, err := NewConntrackCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := make(chan prometheus.Metric, 10)
+	if err := cc.Update(ch); !errors.Is(err, ErrNoData) {
+		t.Fatalf("want ErrNoData, got %v", err)
+	}
+	if len(ch) != 0 {
+		t.Errorf("want no metrics, got %d", len(ch))
+	}
+}
@@ -20,12 +20,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
 	"sync"
+	"time"
 
 	"golang.org/x/exp/maps"
 
@@ -49,10 +51,16 @@ type cpuCollector struct {
 	cpuIsolated        *prometheus.Desc
 	logger             *slog.Logger
 	cpuOnline          *prometheus.Desc
+	cpuUtilization     *prometheus.Desc
 	cpuStats           map[int64]procfs.CPUStat
 	cpuStatsMutex      sync.Mutex
 	isolatedCpus       []uint16
 
+	// Snapshot of cpuStats at the previous scrape, only kept for
+	// --collector.cpu.derived-utilization.
+	prevCPUStats map[int64]procfs.CPUStat
+	prevStatTime time.Time
+
 	cpuFlagsIncludeRegexp *regexp.Regexp
 	cpuBugsIncludeRegexp  *regexp.Regexp
 }
@@ -63,6 +71,9 @@ const jumpBackSeconds = 3.0
 var (
 	enableCPUGuest       = kingpin.Flag("collector.cpu.guest", "Enables metric node_cpu_guest_seconds_total").Default("true").Bool()
 	enableCPUInfo        = kingpin.Flag("collector.cpu.info", "Enables metric cpu_info").Bool()
+	enableCPUUtilization = kingpin.Flag("collector.cpu.derived-utilization", "Enables metric node_cpu_utilization_ratio, computed from the CPU times since the previous scrape").Bool()
+	enableCPUPerCore     = kingpin.Flag("collector.cpu.per-core", "Expose the CPU times of each CPU, disable to sum them up into a single cpu=\"total\" series per mode. node_cpu_utilization_ratio of the total is the average over all CPUs").Default("true").Bool()
+	enableCPUGuestModes  = kingpin.Flag("collector.cpu.guest-modes", "Subtract guest time from the user and nice modes of node_cpu_seconds_total and expose it as the guest and guest_nice modes instead").Bool()
 	flagsInclude         = kingpin.Flag("collector.cpu.info.flags-include", "Filter the `flags` field in cpuInfo with a value that must be a regular expression").String()
 	bugsInclude          = kingpin.Flag("collector.cpu.info.bugs-include", "Filter the `bugs` field in cpuInfo with a value that must be a regular expression").String()
 	jumpBackDebugMessage = fmt.Sprintf("CPU Idle counter jumped backwards more than %f seconds, possible hotplug event, resetting CPU stats", jumpBackSeconds)
@@ -141,6 +152,11 @@ func NewCPUCollector(logger *slog.Logger) (Collector, error) {
 			"CPUs that are online and being scheduled.",
 			[]string{"cpu"}, nil,
 		),
+		cpuUtilization: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, cpuCollectorSubsystem, "utilization_ratio"),
+			"Ratio of time the CPUs spent in each mode since the previous scrape.",
+			[]string{"cpu", "mode"}, nil,
+		),
 		logger:       logger,
 		isolatedCpus: isolcpus,
 		cpuStats:     make(map[int64]procfs.CPUStat),
@@ -386,16 +402,20 @@ func (c *cpuCollector) updateStat(ch chan<- prometheus.Metric) error {
 	// Acquire a lock to read the stats.
 	c.cpuStatsMutex.Lock()
 	defer c.cpuStatsMutex.Unlock()
-	for cpuID, cpuStat := range c.cpuStats {
-		cpuNum := strconv.Itoa(int(cpuID))
-		ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, cpuStat.User, cpuNum, "user")
-		ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, cpuStat.Nice, cpuNum, "nice")
-		ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, cpuStat.System, cpuNum, "system")
-		ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, cpuStat.Idle, cpuNum, "idle")
-		ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, cpuStat.Iowait, cpuNum, "iowait")
-		ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, cpuStat.IRQ, cpuNum, "irq")
-		ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, cpuStat.SoftIRQ, cpuNum, "softirq")
-		ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, cpuStat.Steal, cpuNum, "steal")
+	cpuStats := map[string]procfs.CPUStat{}
+	if *enableCPUPerCore {
+		for cpuID, cpuStat := range c.cpuStats {
+			cpuStats[strconv.Itoa(int(cpuID))] = cpuStat
+		}
+	} else {
+		// Summing up the per-CPU stats rather than using the cpu line of
+		// /proc/stat keeps the total from jumping backwards with them.
+		cpuStats["total"] = sumCPUStats(c.cpuStats)
+	}
+	for cpuNum, cpuStat := range cpuStats {
+		for mode, value := range cpuModeTimes(cpuStat) {
+			ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, value, cpuNum, mode)
+		}
 
 		if *enableCPUGuest {
 			// Guest CPU is also accounted for in cpuStat.User and cpuStat.Nice, expose these as separate metrics.
@@ -404,9 +424,92 @@ func (c *cpuCollector) updateStat(ch chan<- prometheus.Metric) error {
 		}
 	}
 
+	if *enableCPUUtilization {
+		c.updateUtilization(ch, time.Now())
+	}
+
 	return nil
 }
 
+// cpuModeTimes returns the seconds a CPU spent in each mode of
+// node_cpu_seconds_total.
+func cpuModeTimes(s procfs.CPUStat) map[string]float64 {
+	times := map[string]float64{
+		"user":    s.User,
+		"nice":    s.Nice,
+		"system":  s.System,
+		"idle":    s.Idle,
+		"iowait":  s.Iowait,
+		"irq":     s.IRQ,
+		"softirq": s.SoftIRQ,
+		"steal":   s.Steal,
+	}
+	if *enableCPUGuestModes {
+		// The kernel accounts guest time to user and nice as well. The
+		// counters are read one by one, so don't let them go negative.
+		times["user"] = math.Max(s.User-s.Guest, 0)
+		times["nice"] = math.Max(s.Nice-s.GuestNice, 0)
+		times["guest"] = s.Guest
+		times["guest_nice"] = s.GuestNice
+	}
+	return times
+}
+
+// sumCPUStats returns the sum of the stats of all CPUs.
+func sumCPUStats(stats map[int64]procfs.CPUStat) procfs.CPUStat {
+	var sum procfs.CPUStat
+	for _, s := range stats {
+		sum.User += s.User
+		sum.Nice += s.Nice
+		sum.System += s.System
+		sum.Idle += s.Idle
+		sum.Iowait += s.Iowait
+		sum.IRQ += s.IRQ
+		sum.SoftIRQ += s.SoftIRQ
+		sum.Steal += s.Steal
+		sum.Guest += s.Guest
+		sum.GuestNice += s.GuestNice
+	}
+	return sum
+}
+
+// updateUtilization exposes the CPU time spent in each mode since the
+// previous call divided by the elapsed time, and stores the current stats
+// for the next call. Nothing is exposed on the first call. The caller must
+// hold cpuStatsMutex.
+func (c *cpuCollector) updateUtilization(ch chan<- prometheus.Metric, now time.Time) {
+	if elapsed := now.Sub(c.prevStatTime).Seconds(); !c.prevStatTime.IsZero() && elapsed > 0 {
+		total := map[string]float64{}
+		cpus := 0
+		for cpuID, cur := range c.cpuStats {
+			prev, ok := c.prevCPUStats[cpuID]
+			if !ok || cur.Idle < prev.Idle {
+				// The CPU came online or its stats were reset since the previous scrape.
+				continue
+			}
+			cpuNum := strconv.Itoa(int(cpuID))
+			prevTimes := cpuModeTimes(prev)
+			for mode, value := range cpuModeTimes(cur) {
+				delta := value - prevTimes[mode]
+				if !*enableCPUPerCore {
+					total[mode] += delta
+					continue
+				}
+				ch <- prometheus.MustNewConstMetric(c.cpuUtilization, prometheus.GaugeValue, delta/elapsed, cpuNum, mode)
+			}
+			cpus++
+		}
+		if !*enableCPUPerCore && cpus > 0 {
+			for mode, delta := range total {
+				ch <- prometheus.MustNewConstMetric(c.cpuUtilization, prometheus.GaugeValue, delta/elapsed/float64(cpus), "total", mode)
+			}
+		}
+	}
+
+	c.prevCPUStats = maps.Clone(c.cpuStats)
+	c.prevStatTime = now
+}
+
 // updateCPUStats updates the internal cache of CPU stats.
 func (c *cpuCollector) updateCPUStats(newStats map[int64]procfs.CPUStat) {
 
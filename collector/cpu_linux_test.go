@@ -20,8 +20,14 @@ import (
 	"io"
 	"log/slog"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/procfs"
 )
 
@@ -201,3 +207,212 @@ func TestCPUOffline(t *testing.T) {
 	}
 
 }
+
+func TestCPUUtilization(t *testing.T) {
+	c := makeTestCPUCollector(map[int64]procfs.CPUStat{
+		0: {User: 100.0, System: 50.0, Idle: 1000.0, Iowait: 10.0},
+	})
+	c.cpuUtilization = prometheus.NewDesc("node_cpu_utilization_ratio", "", []string{"cpu", "mode"}, nil)
+
+	begin := time.Unix(1700000000, 0)
+	ch := make(chan prometheus.Metric, 100)
+	c.updateUtilization(ch, begin)
+	if len(ch) != 0 {
+		t.Fatalf("expected no metrics on first scrape, got %d", len(ch))
+	}
+
+	c.updateCPUStats(map[int64]procfs.CPUStat{
+		0: {User: 102.0, System: 51.0, Idle: 1006.0, Iowait: 11.0},
+	})
+	c.updateUtilization(ch, begin.Add(10*time.Second))
+	close(ch)
+
+	want := map[string]float64{
+		"user":    0.2,
+		"nice":    0,
+		"system":  0.1,
+		"idle":    0.6,
+		"iowait":  0.1,
+		"irq":     0,
+		"softirq": 0,
+		"steal":   0,
+	}
+	got := map[string]float64{}
+	for m := range ch {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range pb.GetLabel() {
+			if l.GetName() == "mode" {
+				got[l.GetValue()] = pb.GetGauge().GetValue()
+			}
+		}
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("want utilization %v, got %v", want, got)
+	}
+}
+
+func TestCPUUtilizationTotal(t *testing.T) {
+	*enableCPUPerCore = false
+	defer func() { *enableCPUPerCore = true }()
+
+	c := makeTestCPUCollector(map[int64]procfs.CPUStat{
+		0: {User: 100.0, Idle: 1000.0},
+		1: {User: 200.0, Idle: 1000.0},
+	})
+	c.cpuUtilization = prometheus.NewDesc("node_cpu_utilization_ratio", "", []string{"cpu", "mode"}, nil)
+
+	begin := time.Unix(1700000000, 0)
+	ch := make(chan prometheus.Metric, 100)
+	c.updateUtilization(ch, begin)
+	// CPU 2 came online, it has no previous stats.
+	c.updateCPUStats(map[int64]procfs.CPUStat{
+		0: {User: 108.0, Idle: 1002.0},
+		1: {User: 202.0, Idle: 1008.0},
+		2: {User: 50.0, Idle: 50.0},
+	})
+	c.updateUtilization(ch, begin.Add(10*time.Second))
+	close(ch)
+
+	got := map[string]float64{}
+	for m := range ch {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		labels := map[string]string{}
+		for _, l := range pb.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["cpu"] != "total" {
+			t.Fatalf("want only cpu=\"total\", got %v", labels)
+		}
+		got[labels["mode"]] = pb.GetGauge().GetValue()
+	}
+	if got["user"] != 0.5 || got["idle"] != 0.5 || got["system"] != 0 {
+		t.Fatalf("want the average utilization of CPUs 0 and 1, got %v", got)
+	}
+}
+
+type testCPUStatCollector struct {
+	c *cpuCollector
+}
+
+func (c testCPUStatCollector) Collect(ch chan<- prometheus.Metric) {
+	c.c.updateStat(ch)
+}
+
+func (c testCPUStatCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestCPUStatModes(t *testing.T) {
+	// fixtures/proc_baremetal is a KVM host running guests, which don't
+	// steal from it. fixtures/proc_virtualized is a VM, which runs no guests
+	// but has time stolen by its host.
+	for _, test := range []struct {
+		name   string
+		procfs string
+		flags  []string
+		want   string
+	}{
+		{
+			name:   "bare metal per core with guest modes",
+			procfs: "fixtures/proc_baremetal",
+			flags:  []string{"--collector.cpu.guest-modes"},
+			want: `# HELP node_cpu_guest_seconds_total Seconds the CPUs spent in guests (VMs) for each mode.
+				# TYPE node_cpu_guest_seconds_total counter
+				node_cpu_guest_seconds_total{cpu="0",mode="nice"} 1
+				node_cpu_guest_seconds_total{cpu="0",mode="user"} 4
+				node_cpu_guest_seconds_total{cpu="1",mode="nice"} 0.5
+				node_cpu_guest_seconds_total{cpu="1",mode="user"} 6
+				# HELP node_cpu_seconds_total Seconds the CPUs spent in each mode.
+				# TYPE node_cpu_seconds_total counter
+				node_cpu_seconds_total{cpu="0",mode="guest"} 4
+				node_cpu_seconds_total{cpu="0",mode="guest_nice"} 1
+				node_cpu_seconds_total{cpu="0",mode="idle"} 50
+				node_cpu_seconds_total{cpu="0",mode="iowait"} 0.5
+				node_cpu_seconds_total{cpu="0",mode="irq"} 1
+				node_cpu_seconds_total{cpu="0",mode="nice"} 1
+				node_cpu_seconds_total{cpu="0",mode="softirq"} 1.5
+				node_cpu_seconds_total{cpu="0",mode="steal"} 0
+				node_cpu_seconds_total{cpu="0",mode="system"} 3
+				node_cpu_seconds_total{cpu="0",mode="user"} 6
+				node_cpu_seconds_total{cpu="1",mode="guest"} 6
+				node_cpu_seconds_total{cpu="1",mode="guest_nice"} 0.5
+				node_cpu_seconds_total{cpu="1",mode="idle"} 40
+				node_cpu_seconds_total{cpu="1",mode="iowait"} 1
+				node_cpu_seconds_total{cpu="1",mode="irq"} 0.5
+				node_cpu_seconds_total{cpu="1",mode="nice"} 0.5
+				node_cpu_seconds_total{cpu="1",mode="softirq"} 0.5
+				node_cpu_seconds_total{cpu="1",mode="steal"} 0
+				node_cpu_seconds_total{cpu="1",mode="system"} 5
+				node_cpu_seconds_total{cpu="1",mode="user"} 14
+`,
+		},
+		{
+			// Without guest modes, user and nice include the guest time.
+			name:   "bare metal total",
+			procfs: "fixtures/proc_baremetal",
+			flags:  []string{"--no-collector.cpu.per-core"},
+			want: `# HELP node_cpu_guest_seconds_total Seconds the CPUs spent in guests (VMs) for each mode.
+				# TYPE node_cpu_guest_seconds_total counter
+				node_cpu_guest_seconds_total{cpu="total",mode="nice"} 1.5
+				node_cpu_guest_seconds_total{cpu="total",mode="user"} 10
+				# HELP node_cpu_seconds_total Seconds the CPUs spent in each mode.
+				# TYPE node_cpu_seconds_total counter
+				node_cpu_seconds_total{cpu="total",mode="idle"} 90
+				node_cpu_seconds_total{cpu="total",mode="iowait"} 1.5
+				node_cpu_seconds_total{cpu="total",mode="irq"} 1.5
+				node_cpu_seconds_total{cpu="total",mode="nice"} 3
+				node_cpu_seconds_total{cpu="total",mode="softirq"} 2
+				node_cpu_seconds_total{cpu="total",mode="steal"} 0
+				node_cpu_seconds_total{cpu="total",mode="system"} 8
+				node_cpu_seconds_total{cpu="total",mode="user"} 30
+`,
+		},
+		{
+			name:   "virtualized total with guest modes",
+			procfs: "fixtures/proc_virtualized",
+			flags:  []string{"--no-collector.cpu.per-core", "--collector.cpu.guest-modes"},
+			want: `# HELP node_cpu_guest_seconds_total Seconds the CPUs spent in guests (VMs) for each mode.
+				# TYPE node_cpu_guest_seconds_total counter
+				node_cpu_guest_seconds_total{cpu="total",mode="nice"} 0
+				node_cpu_guest_seconds_total{cpu="total",mode="user"} 0
+				# HELP node_cpu_seconds_total Seconds the CPUs spent in each mode.
+				# TYPE node_cpu_seconds_total counter
+				node_cpu_seconds_total{cpu="total",mode="guest"} 0
+				node_cpu_seconds_total{cpu="total",mode="guest_nice"} 0
+				node_cpu_seconds_total{cpu="total",mode="idle"} 121
+				node_cpu_seconds_total{cpu="total",mode="iowait"} 1.5
+				node_cpu_seconds_total{cpu="total",mode="irq"} 0
+				node_cpu_seconds_total{cpu="total",mode="nice"} 0.5
+				node_cpu_seconds_total{cpu="total",mode="softirq"} 1.5
+				node_cpu_seconds_total{cpu="total",mode="steal"} 4
+				node_cpu_seconds_total{cpu="total",mode="system"} 7
+				node_cpu_seconds_total{cpu="total",mode="user"} 32
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := kingpin.CommandLine.Parse(append([]string{"--path.procfs", test.procfs, "--path.sysfs", "fixtures/sys"}, test.flags...)); err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				*procPath = "fixtures/proc"
+				*enableCPUPerCore, *enableCPUGuestModes = true, false
+			}()
+
+			c, err := NewCPUCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutil.CollectAndCompare(testCPUStatCollector{c.(*cpuCollector)}, strings.NewReader(test.want),
+				"node_cpu_seconds_total", "node_cpu_guest_seconds_total"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nocpu
+// +build !nocpu
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testCPUFreqCollector struct {
+	cc Collector
+}
+
+func (c testCPUFreqCollector) Collect(ch chan<- prometheus.Metric) {
+	c.cc.Update(ch)
+}
+
+func (c testCPUFreqCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestCPUFreq(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	cc, err := NewCPUFreqCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The fixtures only provide the scaling_* files, as seen when the
+	// cpuinfo_* files are not readable, so no node_cpu_frequency_* metrics
+	// are exposed.
+	want := `
+		# HELP node_cpu_scaling_frequency_hertz Current scaled CPU thread frequency in hertz.
+		# TYPE node_cpu_scaling_frequency_hertz gauge
+		node_cpu_scaling_frequency_hertz{cpu="0"} 1.699981e+09
+		node_cpu_scaling_frequency_hertz{cpu="1"} 1.699981e+09
+		node_cpu_scaling_frequency_hertz{cpu="2"} 8e+06
+		node_cpu_scaling_frequency_hertz{cpu="3"} 8e+06
+		# HELP node_cpu_scaling_frequency_max_hertz Maximum scaled CPU thread frequency in hertz.
+		# TYPE node_cpu_scaling_frequency_max_hertz gauge
+		node_cpu_scaling_frequency_max_hertz{cpu="0"} 3.7e+09
+		node_cpu_scaling_frequency_max_hertz{cpu="1"} 3.7e+09
+		node_cpu_scaling_frequency_max_hertz{cpu="2"} 4.2e+09
+		node_cpu_scaling_frequency_max_hertz{cpu="3"} 4.2e+09
+		# HELP node_cpu_scaling_frequency_min_hertz Minimum scaled CPU thread frequency in hertz.
+		# TYPE node_cpu_scaling_frequency_min_hertz gauge
+		node_cpu_scaling_frequency_min_hertz{cpu="0"} 8e+08
+		node_cpu_scaling_frequency_min_hertz{cpu="1"} 8e+08
+		node_cpu_scaling_frequency_min_hertz{cpu="2"} 1e+06
+		node_cpu_scaling_frequency_min_hertz{cpu="3"} 1e+06
+		# HELP node_cpu_scaling_governor Current enabled CPU frequency governor.
+		# TYPE node_cpu_scaling_governor gauge
+		node_cpu_scaling_governor{cpu="0",governor="performance"} 0
+		node_cpu_scaling_governor{cpu="0",governor="powersave"} 1
+		node_cpu_scaling_governor{cpu="1",governor="performance"} 0
+		node_cpu_scaling_governor{cpu="1",governor="powersave"} 1
+		node_cpu_scaling_governor{cpu="2",governor="performance"} 0
+		node_cpu_scaling_governor{cpu="2",governor="powersave"} 1
+		node_cpu_scaling_governor{cpu="3",governor="performance"} 0
+		node_cpu_scaling_governor{cpu="3",governor="powersave"} 1
+`
+	if err := testutil.CollectAndCompare(testCPUFreqCollector{cc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
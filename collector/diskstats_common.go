@@ -19,7 +19,9 @@ package collector
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
+	"regexp"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
@@ -35,7 +37,7 @@ var (
 	diskstatsDeviceExcludeSet bool
 	diskstatsDeviceExclude    = kingpin.Flag(
 		"collector.diskstats.device-exclude",
-		"Regexp of diskstats devices to exclude (mutually exclusive to device-include).",
+		"Regexp of diskstats devices to exclude, takes precedence over device-include.",
 	).Default(diskstatsDefaultIgnoredDevices).PreAction(func(c *kingpin.ParseContext) error {
 		diskstatsDeviceExcludeSet = true
 		return nil
@@ -45,7 +47,7 @@ var (
 		"DEPRECATED: Use collector.diskstats.device-exclude",
 	).Hidden().String()
 
-	diskstatsDeviceInclude = kingpin.Flag("collector.diskstats.device-include", "Regexp of diskstats devices to include (mutually exclusive to device-exclude).").String()
+	diskstatsDeviceInclude = kingpin.Flag("collector.diskstats.device-include", "Regexp of diskstats devices to include, devices matching device-exclude are still excluded.").String()
 
 	readsCompletedDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, diskSubsystem, "reads_completed_total"),
@@ -102,16 +104,21 @@ func newDiskstatsDeviceFilter(logger *slog.Logger) (deviceFilter, error) {
 		}
 	}
 
-	if *diskstatsDeviceExclude != "" && *diskstatsDeviceInclude != "" {
-		return deviceFilter{}, errors.New("device-exclude & device-include are mutually exclusive")
-	}
-
+	// Both patterns may be set, a device matching both is excluded. This
+	// keeps the default exclude of virtual devices and partitions in place
+	// when only device-include is given.
 	if *diskstatsDeviceExclude != "" {
+		if _, err := regexp.Compile(*diskstatsDeviceExclude); err != nil {
+			return deviceFilter{}, fmt.Errorf("invalid --collector.diskstats.device-exclude: %w", err)
+		}
 		logger.Info("Parsed flag --collector.diskstats.device-exclude", "flag", *diskstatsDeviceExclude)
 	}
 
 	if *diskstatsDeviceInclude != "" {
-		logger.Info("Parsed Flag --collector.diskstats.device-include", "flag", *diskstatsDeviceInclude)
+		if _, err := regexp.Compile(*diskstatsDeviceInclude); err != nil {
+			return deviceFilter{}, fmt.Errorf("invalid --collector.diskstats.device-include: %w", err)
+		}
+		logger.Info("Parsed flag --collector.diskstats.device-include", "flag", *diskstatsDeviceInclude)
 	}
 
 	return newDeviceFilter(*diskstatsDeviceExclude, *diskstatsDeviceInclude), nil
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs/blockdevice"
 )
@@ -65,6 +66,8 @@ const (
 	udevSCSIIdentSerial         = "SCSI_IDENT_SERIAL"
 )
 
+var diskstatsResolveDMNames = kingpin.Flag("collector.diskstats.resolve-dm-names", "Use the device-mapper name from /sys/block/dm-N/dm/name as device label instead of dm-N.").Bool()
+
 type typedFactorDesc struct {
 	desc      *prometheus.Desc
 	valueType prometheus.ValueType
@@ -83,7 +86,11 @@ type diskstatsCollector struct {
 	descs                   []typedFactorDesc
 	filesystemInfoDesc      typedFactorDesc
 	deviceMapperInfoDesc    typedFactorDesc
+	schedulerInfoDesc       typedFactorDesc
+	nrRequestsDesc          typedFactorDesc
+	rotationalDesc          typedFactorDesc
 	ataDescs                map[string]typedFactorDesc
+	resolveDMNames          bool
 	logger                  *slog.Logger
 	getUdevDeviceProperties func(uint32, uint32) (udevInfo, error)
 }
@@ -233,6 +240,27 @@ func NewDiskstatsCollector(logger *slog.Logger) (Collector, error) {
 				nil,
 			), valueType: prometheus.GaugeValue,
 		},
+		schedulerInfoDesc: typedFactorDesc{
+			desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, diskSubsystem, "scheduler_info"),
+				"Active I/O scheduler of /sys/block/<block_device>/queue/scheduler.",
+				[]string{"device", "scheduler"},
+				nil,
+			), valueType: prometheus.GaugeValue,
+		},
+		nrRequestsDesc: typedFactorDesc{
+			desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, diskSubsystem, "nr_requests"),
+				"Number of requests that may be allocated in the block layer for reads or writes, from /sys/block/<block_device>/queue/nr_requests.",
+				diskLabelNames,
+				nil,
+			), valueType: prometheus.GaugeValue,
+		},
+		rotationalDesc: typedFactorDesc{
+			desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, diskSubsystem, "rotational"),
+				"Whether the device is of rotational type, from /sys/block/<block_device>/queue/rotational.",
+				diskLabelNames,
+				nil,
+			), valueType: prometheus.GaugeValue,
+		},
 		ataDescs: map[string]typedFactorDesc{
 			udevIDATAWriteCache: {
 				desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, diskSubsystem, "ata_write_cache"),
@@ -256,7 +284,8 @@ func NewDiskstatsCollector(logger *slog.Logger) (Collector, error) {
 				), valueType: prometheus.GaugeValue,
 			},
 		},
-		logger: logger,
+		resolveDMNames: *diskstatsResolveDMNames,
+		logger:         logger,
 	}
 
 	// Only enable getting device properties from udev if the directory is readable.
@@ -280,6 +309,12 @@ func (c *diskstatsCollector) Update(ch chan<- prometheus.Metric) error {
 		if c.deviceFilter.ignored(dev) {
 			continue
 		}
+		// Filtering and sysfs lookups use the kernel name, only the label
+		// is resolved.
+		label := dev
+		if c.resolveDMNames {
+			label = c.dmName(dev)
+		}
 
 		info, err := getUdevDeviceProperties(stats.MajorNumber, stats.MinorNumber)
 		if err != nil {
@@ -300,7 +335,7 @@ func (c *diskstatsCollector) Update(ch chan<- prometheus.Metric) error {
 			c.logger.Debug("Failed to get block device queue stats", "device", dev, "err", err)
 		}
 
-		ch <- c.infoDesc.mustNewConstMetric(1.0, dev,
+		ch <- c.infoDesc.mustNewConstMetric(1.0, label,
 			fmt.Sprint(stats.MajorNumber),
 			fmt.Sprint(stats.MinorNumber),
 			info[udevIDPath],
@@ -311,6 +346,15 @@ func (c *diskstatsCollector) Update(ch chan<- prometheus.Metric) error {
 			strconv.FormatUint(queueStats.Rotational, 2),
 		)
 
+		// Only top-level block devices have a queue, not their partitions.
+		if err == nil {
+			if queueStats.SchedulerCurrent != "" {
+				ch <- c.schedulerInfoDesc.mustNewConstMetric(1.0, label, queueStats.SchedulerCurrent)
+			}
+			ch <- c.nrRequestsDesc.mustNewConstMetric(float64(queueStats.NRRequests), label)
+			ch <- c.rotationalDesc.mustNewConstMetric(float64(queueStats.Rotational), label)
+		}
+
 		statCount := stats.IoStatsCount - 3 // Total diskstats record count, less MajorNumber, MinorNumber and DeviceName
 
 		for i, val := range []float64{
@@ -335,11 +379,11 @@ func (c *diskstatsCollector) Update(ch chan<- prometheus.Metric) error {
 			if i >= statCount {
 				break
 			}
-			ch <- c.descs[i].mustNewConstMetric(val, dev)
+			ch <- c.descs[i].mustNewConstMetric(val, label)
 		}
 
 		if fsType := info[udevIDFSType]; fsType != "" {
-			ch <- c.filesystemInfoDesc.mustNewConstMetric(1.0, dev,
+			ch <- c.filesystemInfoDesc.mustNewConstMetric(1.0, label,
 				fsType,
 				info[udevIDFSUsage],
 				info[udevIDFSUUID],
@@ -348,7 +392,7 @@ func (c *diskstatsCollector) Update(ch chan<- prometheus.Metric) error {
 		}
 
 		if name := info[udevDMName]; name != "" {
-			ch <- c.deviceMapperInfoDesc.mustNewConstMetric(1.0, dev,
+			ch <- c.deviceMapperInfoDesc.mustNewConstMetric(1.0, label,
 				name,
 				info[udevDMUUID],
 				info[udevDMVGName],
@@ -366,7 +410,7 @@ func (c *diskstatsCollector) Update(ch chan<- prometheus.Metric) error {
 				}
 
 				if value, err := strconv.ParseFloat(str, 64); err == nil {
-					ch <- desc.mustNewConstMetric(value, dev)
+					ch <- desc.mustNewConstMetric(value, label)
 				} else {
 					c.logger.Error("Failed to parse ATA value", "err", err)
 				}
@@ -376,6 +420,23 @@ func (c *diskstatsCollector) Update(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
+// dmName returns the device-mapper name of a dm-N device, e.g. the LVM volume
+// or crypt mapping, falling back to dev if it has none.
+func (c *diskstatsCollector) dmName(dev string) string {
+	if !strings.HasPrefix(dev, "dm-") {
+		return dev
+	}
+	name, err := os.ReadFile(sysFilePath(fmt.Sprintf("block/%s/dm/name", dev)))
+	if err != nil {
+		c.logger.Debug("Failed to resolve device-mapper name", "device", dev, "err", err)
+		return dev
+	}
+	if name := strings.TrimSpace(string(name)); name != "" {
+		return name
+	}
+	return dev
+}
+
 func getUdevDeviceProperties(major, minor uint32) (udevInfo, error) {
 	filename := udevDataFilePath(fmt.Sprintf("b%d:%d", major, minor))
 
@@ -179,6 +179,9 @@ node_disk_io_time_weighted_seconds_total{device="sdb"} 67.07000000000001
 node_disk_io_time_weighted_seconds_total{device="sdc"} 17.07
 node_disk_io_time_weighted_seconds_total{device="sr0"} 0
 node_disk_io_time_weighted_seconds_total{device="vda"} 2.0778722280000001e+06
+# HELP node_disk_nr_requests Number of requests that may be allocated in the block layer for reads or writes, from /sys/block/<block_device>/queue/nr_requests.
+# TYPE node_disk_nr_requests gauge
+node_disk_nr_requests{device="sda"} 64
 # HELP node_disk_read_bytes_total The total number of bytes read successfully.
 # TYPE node_disk_read_bytes_total counter
 node_disk_read_bytes_total{device="dm-0"} 5.13708655616e+11
@@ -247,6 +250,12 @@ node_disk_reads_merged_total{device="sdb"} 841
 node_disk_reads_merged_total{device="sdc"} 141
 node_disk_reads_merged_total{device="sr0"} 0
 node_disk_reads_merged_total{device="vda"} 15386
+# HELP node_disk_rotational Whether the device is of rotational type, from /sys/block/<block_device>/queue/rotational.
+# TYPE node_disk_rotational gauge
+node_disk_rotational{device="sda"} 1
+# HELP node_disk_scheduler_info Active I/O scheduler of /sys/block/<block_device>/queue/scheduler.
+# TYPE node_disk_scheduler_info gauge
+node_disk_scheduler_info{device="sda",scheduler="bfq"} 1
 # HELP node_disk_write_time_seconds_total This is the total number of seconds spent by all writes.
 # TYPE node_disk_write_time_seconds_total counter
 node_disk_write_time_seconds_total{device="dm-0"} 1.1585578e+06
@@ -343,3 +352,95 @@ node_disk_written_bytes_total{device="vda"} 1.0938236928e+11
 		t.Fatal(err)
 	}
 }
+
+func TestDiskStatsResolveDMNames(t *testing.T) {
+	*sysPath = "fixtures/sys"
+	*procPath = "fixtures/proc"
+	*udevDataPath = "fixtures/udev/data"
+	*diskstatsDeviceExclude = "^(z?ram|loop|fd|(h|s|v|xv)d[a-z]|nvme\\d+n\\d+p)\\d+$"
+	*diskstatsResolveDMNames = true
+	defer func() { *diskstatsResolveDMNames = false }()
+
+	// Only dm-0 and dm-1 have a dm/name in the sysfs fixtures, the others
+	// keep their kernel name.
+	testcase := `# HELP node_disk_device_mapper_info Info about disk device mapper.
+# TYPE node_disk_device_mapper_info gauge
+node_disk_device_mapper_info{device="dm-2",lv_layer="",lv_name="root",name="system-root",uuid="LVM-NWEDo8q5ABDyJuC3F8veKNyWfYmeIBfFMS4MF3HakzUhkk7ekDm6fJTHkl2fYHe7",vg_name="system"} 1
+node_disk_device_mapper_info{device="dm-3",lv_layer="",lv_name="var",name="system-var",uuid="LVM-hrxHo0rlZ6U95ku5841Lpd17bS1Z7V7lrtEE60DVgE6YEOCdS9gcDGyonWim4hGP",vg_name="system"} 1
+node_disk_device_mapper_info{device="dm-4",lv_layer="",lv_name="tmp",name="system-tmp",uuid="LVM-XTNGOHjPWLHcxmJmVu5cWTXEtuzqDeBkdEHAZW5q9LxWQ2d4mb5CchUQzUPJpl8H",vg_name="system"} 1
+node_disk_device_mapper_info{device="dm-5",lv_layer="",lv_name="home",name="system-home",uuid="LVM-MtoJaWTpjWRXlUnNFlpxZauTEuYlMvGFutigEzCCrfj8CNh6jCRi5LQJXZCpLjPf",vg_name="system"} 1
+node_disk_device_mapper_info{device="nvme0n1_crypt",lv_layer="",lv_name="",name="nvme0n1_crypt",uuid="CRYPT-LUKS2-jolaulot80fy9zsiobkxyxo7y2dqeho2-nvme0n1_crypt",vg_name=""} 1
+node_disk_device_mapper_info{device="system-swap_1",lv_layer="",lv_name="swap_1",name="system-swap_1",uuid="LVM-wbGqQEBL9SxrW2DLntJwgg8fAv946hw3Tvjqh0v31fWgxEtD4BoHO0lROWFUY65T",vg_name="system"} 1
+# HELP node_disk_reads_completed_total The total number of reads completed successfully.
+# TYPE node_disk_reads_completed_total counter
+node_disk_reads_completed_total{device="dm-2"} 11571
+node_disk_reads_completed_total{device="dm-3"} 3870
+node_disk_reads_completed_total{device="dm-4"} 392
+node_disk_reads_completed_total{device="dm-5"} 3729
+node_disk_reads_completed_total{device="mmcblk0"} 192
+node_disk_reads_completed_total{device="mmcblk0p1"} 17
+node_disk_reads_completed_total{device="mmcblk0p2"} 95
+node_disk_reads_completed_total{device="nvme0n1"} 47114
+node_disk_reads_completed_total{device="nvme0n1_crypt"} 5.9910002e+07
+node_disk_reads_completed_total{device="sda"} 2.5354637e+07
+node_disk_reads_completed_total{device="sdb"} 326552
+node_disk_reads_completed_total{device="sdc"} 126552
+node_disk_reads_completed_total{device="sr0"} 0
+node_disk_reads_completed_total{device="system-swap_1"} 388
+node_disk_reads_completed_total{device="vda"} 1.775784e+06
+`
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	collector, err := NewDiskstatsCollector(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testutil.CollectAndCompare(testDiskStatsCollector{collector}, strings.NewReader(testcase),
+		"node_disk_device_mapper_info", "node_disk_reads_completed_total")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiskStatsDeviceIncludeExclude(t *testing.T) {
+	*sysPath = "fixtures/sys"
+	*procPath = "fixtures/proc"
+	*udevDataPath = "fixtures/udev/data"
+	defer func(exclude, include string) {
+		*diskstatsDeviceExclude, *diskstatsDeviceInclude = exclude, include
+	}(*diskstatsDeviceExclude, *diskstatsDeviceInclude)
+	// The default exclude still drops the sd partitions, the explicit one
+	// wins over the include for the mmcblk0 partitions.
+	*diskstatsDeviceExclude = "^(z?ram|loop|fd|(h|s|v|xv)d[a-z]|nvme\\d+n\\d+p|mmcblk0p)\\d+$"
+	*diskstatsDeviceInclude = "^(sd|mmcblk0)"
+
+	// sda and mmcblk0 only have the fields of kernels before 4.18.
+	testcase := `# HELP node_disk_discards_completed_total The total number of discards completed successfully.
+# TYPE node_disk_discards_completed_total counter
+node_disk_discards_completed_total{device="sdb"} 68851
+node_disk_discards_completed_total{device="sdc"} 18851
+# HELP node_disk_flush_requests_total The total number of flush requests completed successfully
+# TYPE node_disk_flush_requests_total counter
+node_disk_flush_requests_total{device="sdc"} 1555
+# HELP node_disk_reads_completed_total The total number of reads completed successfully.
+# TYPE node_disk_reads_completed_total counter
+node_disk_reads_completed_total{device="mmcblk0"} 192
+node_disk_reads_completed_total{device="sda"} 2.5354637e+07
+node_disk_reads_completed_total{device="sdb"} 326552
+node_disk_reads_completed_total{device="sdc"} 126552
+`
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	collector, err := NewDiskstatsCollector(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testutil.CollectAndCompare(testDiskStatsCollector{collector}, strings.NewReader(testcase),
+		"node_disk_discards_completed_total", "node_disk_flush_requests_total", "node_disk_reads_completed_total")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	*diskstatsDeviceInclude = "("
+	if _, err := NewDiskstatsCollector(logger); err == nil {
+		t.Fatal("expected an error for an invalid device-include")
+	}
+}
@@ -23,10 +23,15 @@ import (
 	"os"
 	"strings"
 
+	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs/sysfs"
 )
 
+var (
+	dmiIdentifiers = kingpin.Flag("collector.dmi.identifiers", "Include the serial numbers and product UUID, which identify single machines, in node_dmi_info.").Default("false").Bool()
+)
+
 type dmiCollector struct {
 	infoDesc *prometheus.Desc
 	values   []string
@@ -53,29 +58,34 @@ func NewDMICollector(logger *slog.Logger) (Collector, error) {
 		}
 	}
 
-	var labels, values []string
-	for label, value := range map[string]*string{
+	fields := map[string]*string{
 		"bios_date":         dmi.BiosDate,
 		"bios_release":      dmi.BiosRelease,
 		"bios_vendor":       dmi.BiosVendor,
 		"bios_version":      dmi.BiosVersion,
 		"board_asset_tag":   dmi.BoardAssetTag,
 		"board_name":        dmi.BoardName,
-		"board_serial":      dmi.BoardSerial,
 		"board_vendor":      dmi.BoardVendor,
 		"board_version":     dmi.BoardVersion,
 		"chassis_asset_tag": dmi.ChassisAssetTag,
-		"chassis_serial":    dmi.ChassisSerial,
+		"chassis_type":      dmi.ChassisType,
 		"chassis_vendor":    dmi.ChassisVendor,
 		"chassis_version":   dmi.ChassisVersion,
 		"product_family":    dmi.ProductFamily,
 		"product_name":      dmi.ProductName,
-		"product_serial":    dmi.ProductSerial,
 		"product_sku":       dmi.ProductSKU,
-		"product_uuid":      dmi.ProductUUID,
 		"product_version":   dmi.ProductVersion,
 		"system_vendor":     dmi.SystemVendor,
-	} {
+	}
+	if *dmiIdentifiers {
+		fields["board_serial"] = dmi.BoardSerial
+		fields["chassis_serial"] = dmi.ChassisSerial
+		fields["product_serial"] = dmi.ProductSerial
+		fields["product_uuid"] = dmi.ProductUUID
+	}
+
+	var labels, values []string
+	for label, value := range fields {
 		if value != nil {
 			labels = append(labels, label)
 			values = append(values, strings.ToValidUTF8(*value, "�"))
@@ -88,7 +98,7 @@ func NewDMICollector(logger *slog.Logger) (Collector, error) {
 			prometheus.BuildFQName(namespace, "dmi", "info"),
 			"A metric with a constant '1' value labeled by bios_date, bios_release, bios_vendor, bios_version, "+
 				"board_asset_tag, board_name, board_serial, board_vendor, board_version, chassis_asset_tag, "+
-				"chassis_serial, chassis_vendor, chassis_version, product_family, product_name, product_serial, "+
+				"chassis_serial, chassis_type, chassis_vendor, chassis_version, product_family, product_name, product_serial, "+
 				"product_sku, product_uuid, product_version, system_vendor if provided by DMI.",
 			labels, nil,
 		),
@@ -0,0 +1,99 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux && !nodmi
+// +build linux,!nodmi
+
+package collector
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestDMICollector(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		args        []string
+		identifiers bool
+	}{
+		{
+			name: "default",
+			args: []string{"--path.sysfs", "fixtures/sys"},
+		},
+		{
+			name:        "identifiers",
+			args:        []string{"--path.sysfs", "fixtures/sys", "--collector.dmi.identifiers"},
+			identifiers: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := kingpin.CommandLine.Parse(test.args); err != nil {
+				t.Fatal(err)
+			}
+			c, err := NewDMICollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ch := make(chan prometheus.Metric, 1)
+			if err := c.Update(ch); err != nil {
+				t.Fatal(err)
+			}
+			close(ch)
+			var m dto.Metric
+			if err := (<-ch).Write(&m); err != nil {
+				t.Fatal(err)
+			}
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+
+			for label, want := range map[string]string{
+				"bios_version":  "2.2.4",
+				"chassis_type":  "23",
+				"product_name":  "PowerEdge R6515",
+				"system_vendor": "Dell Inc.",
+			} {
+				if got := labels[label]; got != want {
+					t.Errorf("want %s %q, got %q", label, want, got)
+				}
+			}
+			for _, label := range []string{"board_serial", "chassis_serial", "product_serial", "product_uuid"} {
+				if _, ok := labels[label]; ok != test.identifiers {
+					t.Errorf("want %s exported %t, got %t", label, test.identifiers, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestDMICollectorNoDMI(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewDMICollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Update(make(chan prometheus.Metric, 1)); !errors.Is(err, ErrNoData) {
+		t.Fatalf("want ErrNoData, got %v", err)
+	}
+}
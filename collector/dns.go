@@ -0,0 +1,136 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nodns
+// +build !nodns
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const dnsSubsystem = "dns"
+
+var (
+	dnsTargets  = kingpin.Flag("collector.dns.targets", "Hostname to resolve on every scrape. (repeatable)").Strings()
+	dnsResolver = kingpin.Flag("collector.dns.resolver", "DNS server to query, as host or host:port. Uses the system resolver if empty.").Default("").String()
+	dnsTimeout  = kingpin.Flag("collector.dns.timeout", "Timeout of a single lookup.").Default("2s").Duration()
+)
+
+type dnsCollector struct {
+	targets  []string
+	resolver *net.Resolver
+	timeout  time.Duration
+	duration typedDesc
+	success  typedDesc
+	logger   *slog.Logger
+}
+
+func init() {
+	registerCollector(dnsSubsystem, defaultDisabled, NewDNSCollector)
+}
+
+// NewDNSCollector returns a new Collector measuring A-record lookups of the
+// configured targets.
+func NewDNSCollector(logger *slog.Logger) (Collector, error) {
+	if *dnsTimeout <= 0 {
+		return nil, fmt.Errorf("lookup timeout must be positive")
+	}
+	seen := map[string]struct{}{}
+	for _, target := range *dnsTargets {
+		if _, ok := seen[target]; ok {
+			return nil, fmt.Errorf("duplicate target %q in --collector.dns.targets", target)
+		}
+		seen[target] = struct{}{}
+	}
+
+	resolver := net.DefaultResolver
+	if *dnsResolver != "" {
+		server := *dnsResolver
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, "53")
+		}
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, server)
+			},
+		}
+	}
+
+	return &dnsCollector{
+		targets:  *dnsTargets,
+		resolver: resolver,
+		timeout:  *dnsTimeout,
+		duration: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, dnsSubsystem, "lookup_duration_seconds"),
+			"Time taken by the last A-record lookup of the target.",
+			[]string{"target"}, nil,
+		), prometheus.GaugeValue},
+		success: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, dnsSubsystem, "lookup_success"),
+			"Whether the last A-record lookup of the target returned an address.",
+			[]string{"target"}, nil,
+		), prometheus.GaugeValue},
+		logger: logger,
+	}, nil
+}
+
+func (c *dnsCollector) Update(ch chan<- prometheus.Metric) error {
+	if len(c.targets) == 0 {
+		return ErrNoData
+	}
+
+	// Resolve all targets in parallel so a slow resolver costs at most one
+	// timeout per scrape.
+	var wg sync.WaitGroup
+	for _, target := range c.targets {
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+			duration, err := c.lookup(target)
+			success := 1.0
+			if err != nil {
+				c.logger.Debug("DNS lookup failed", "target", target, "err", err)
+				success = 0
+			}
+			ch <- c.duration.mustNewConstMetric(duration.Seconds(), target)
+			ch <- c.success.mustNewConstMetric(success, target)
+		}(target)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (c *dnsCollector) lookup(target string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	ips, err := c.resolver.LookupIP(ctx, "ip4", target)
+	duration := time.Since(start)
+	if err == nil && len(ips) == 0 {
+		err = fmt.Errorf("no A records for %s", target)
+	}
+	return duration, err
+}
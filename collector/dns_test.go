@@ -0,0 +1,84 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nodns
+// +build !nodns
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testDNSCollector struct {
+	dc Collector
+}
+
+func (c testDNSCollector) Collect(ch chan<- prometheus.Metric) {
+	c.dc.Update(ch)
+}
+
+func (c testDNSCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestDNSCollector(t *testing.T) {
+	// Nothing listens on port 1, so only names from the hosts file resolve.
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--collector.dns.targets", "localhost",
+		"--collector.dns.targets", "node-exporter.invalid",
+		"--collector.dns.resolver", "127.0.0.1:1",
+		"--collector.dns.timeout", "500ms",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		*dnsTargets = nil
+		*dnsResolver = ""
+	}()
+
+	dc, err := NewDNSCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_dns_lookup_success Whether the last A-record lookup of the target returned an address.
+		# TYPE node_dns_lookup_success gauge
+		node_dns_lookup_success{target="localhost"} 1
+		node_dns_lookup_success{target="node-exporter.invalid"} 0
+`
+	if err := testutil.CollectAndCompare(testDNSCollector{dc}, strings.NewReader(want), "node_dns_lookup_success"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDNSCollectorDuplicateTarget(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--collector.dns.targets", "localhost",
+		"--collector.dns.targets", "localhost",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *dnsTargets = nil }()
+
+	if _, err := NewDNSCollector(slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("want error for duplicate target")
+	}
+}
@@ -98,7 +98,7 @@ func newDRBDCollector(logger *slog.Logger) (Collector, error) {
 				"network_received_bytes_total",
 				"Total number of bytes received via the network.",
 				prometheus.CounterValue,
-				1,
+				1024,
 			),
 			"dw": newDRBDNumericalMetric(
 				"disk_written_bytes_total",
@@ -201,10 +201,19 @@ func (c *drbdCollector) Update(ch chan<- prometheus.Metric) error {
 	scanner := bufio.NewScanner(file)
 	scanner.Split(bufio.ScanWords)
 	device := "unknown"
+	version := ""
+	devices := 0
 
 	for scanner.Scan() {
 		field := scanner.Text()
 
+		if field == "version:" {
+			if scanner.Scan() {
+				version = scanner.Text()
+			}
+			continue
+		}
+
 		kv := strings.Split(field, ":")
 		if len(kv) != 2 {
 			c.logger.Debug("skipping invalid key:value pair", "field", field)
@@ -214,6 +223,7 @@ func (c *drbdCollector) Update(ch chan<- prometheus.Metric) error {
 		if id, err := strconv.ParseUint(kv[0], 10, 64); err == nil && kv[1] == "" {
 			// New DRBD device encountered.
 			device = fmt.Sprintf("drbd%d", id)
+			devices++
 			continue
 		}
 
@@ -237,6 +247,10 @@ func (c *drbdCollector) Update(ch chan<- prometheus.Metric) error {
 		if m, ok := c.stringPair[kv[0]]; ok {
 			// String pair value.
 			values := strings.Split(kv[1], "/")
+			if len(values) != 2 {
+				c.logger.Debug("skipping invalid string pair", "key", kv[0], "value", kv[1])
+				continue
+			}
 			ch <- prometheus.MustNewConstMetric(
 				m.desc,
 				prometheus.GaugeValue,
@@ -275,6 +289,16 @@ func (c *drbdCollector) Update(ch chan<- prometheus.Metric) error {
 
 		c.logger.Debug("unhandled key-value pair", "key", kv[0], "value", kv[1])
 	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// DRBD 9 only reports its version in /proc/drbd, the state of its
+	// resources is available from drbdsetup instead.
+	if devices == 0 {
+		c.logger.Debug("no devices in stats file", "file", statsFile, "version", version)
+		return ErrNoData
+	}
 
-	return scanner.Err()
+	return nil
 }
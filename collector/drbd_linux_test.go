@@ -0,0 +1,130 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nodrbd
+// +build !nodrbd
+
+package collector
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testDRBDCollector struct {
+	dc Collector
+}
+
+func (c testDRBDCollector) Collect(ch chan<- prometheus.Metric) {
+	c.dc.Update(ch)
+}
+
+func (c testDRBDCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestDRBD(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	dc, err := newDRBDCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_drbd_connected Whether DRBD is connected to the peer.
+		# TYPE node_drbd_connected gauge
+		node_drbd_connected{device="drbd1"} 1
+		# HELP node_drbd_disk_read_bytes_total Net data read from local hard disk; in bytes.
+		# TYPE node_drbd_disk_read_bytes_total counter
+		node_drbd_disk_read_bytes_total{device="drbd1"} 1.2154539008e+11
+		# HELP node_drbd_disk_state_is_up_to_date Whether the disk of the node is up to date.
+		# TYPE node_drbd_disk_state_is_up_to_date gauge
+		node_drbd_disk_state_is_up_to_date{device="drbd1",node="local"} 1
+		node_drbd_disk_state_is_up_to_date{device="drbd1",node="remote"} 1
+		# HELP node_drbd_disk_written_bytes_total Net data written on local hard disk; in bytes.
+		# TYPE node_drbd_disk_written_bytes_total counter
+		node_drbd_disk_written_bytes_total{device="drbd1"} 2.8941845504e+10
+		# HELP node_drbd_network_received_bytes_total Total number of bytes received via the network.
+		# TYPE node_drbd_network_received_bytes_total counter
+		node_drbd_network_received_bytes_total{device="drbd1"} 1.1224075264e+10
+		# HELP node_drbd_network_sent_bytes_total Total number of bytes sent via the network.
+		# TYPE node_drbd_network_sent_bytes_total counter
+		node_drbd_network_sent_bytes_total{device="drbd1"} 1.7740228608e+10
+		# HELP node_drbd_node_role_is_primary Whether the role of the node is in the primary state.
+		# TYPE node_drbd_node_role_is_primary gauge
+		node_drbd_node_role_is_primary{device="drbd1",node="local"} 1
+		node_drbd_node_role_is_primary{device="drbd1",node="remote"} 1
+		# HELP node_drbd_out_of_sync_bytes Amount of data known to be out of sync; in bytes.
+		# TYPE node_drbd_out_of_sync_bytes gauge
+		node_drbd_out_of_sync_bytes{device="drbd1"} 1.2645376e+07
+`
+	if err := testutil.CollectAndCompare(testDRBDCollector{dc}, strings.NewReader(want),
+		"node_drbd_connected", "node_drbd_disk_read_bytes_total", "node_drbd_disk_state_is_up_to_date",
+		"node_drbd_disk_written_bytes_total", "node_drbd_network_received_bytes_total",
+		"node_drbd_network_sent_bytes_total", "node_drbd_node_role_is_primary", "node_drbd_out_of_sync_bytes"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDRBDNoData(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		drbd string
+	}{
+		{
+			name: "module not loaded",
+		},
+		{
+			// DRBD 9 reports the state of its resources via drbdsetup.
+			name: "drbd 9",
+			drbd: `version: 9.2.8 (api:2/proto:86-122)
+GIT-hash: e163b05a76254c0f51f999970e861d72bb16409a build by @buildsystem, 2024-03-21 10:10:41
+Transports (api:20): tcp (9.2.8)
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			proc := t.TempDir()
+			if test.drbd != "" {
+				if err := os.WriteFile(filepath.Join(proc, "drbd"), []byte(test.drbd), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", proc}); err != nil {
+				t.Fatal(err)
+			}
+			dc, err := newDRBDCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ch := make(chan prometheus.Metric, 10)
+			if err := dc.Update(ch); !errors.Is(err, ErrNoData) {
+				t.Fatalf("want ErrNoData, got %v", err)
+			}
+			if n := len(ch); n != 0 {
+				t.Errorf("want no metrics, got %d", n)
+			}
+		})
+	}
+}
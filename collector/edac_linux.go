@@ -19,8 +19,10 @@ package collector
 import (
 	"fmt"
 	"log/slog"
+	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -32,6 +34,7 @@ const (
 var (
 	edacMemControllerRE = regexp.MustCompile(`.*devices/system/edac/mc/mc([0-9]*)`)
 	edacMemCsrowRE      = regexp.MustCompile(`.*devices/system/edac/mc/mc[0-9]*/csrow([0-9]*)`)
+	edacMemDimmRE       = regexp.MustCompile(`.*devices/system/edac/mc/mc[0-9]*/dimm([0-9]*)`)
 )
 
 type edacCollector struct {
@@ -39,6 +42,9 @@ type edacCollector struct {
 	ueCount      *prometheus.Desc
 	csRowCECount *prometheus.Desc
 	csRowUECount *prometheus.Desc
+	dimmCECount  *prometheus.Desc
+	dimmUECount  *prometheus.Desc
+	dimmInfo     *prometheus.Desc
 	logger       *slog.Logger
 }
 
@@ -69,6 +75,21 @@ func NewEdacCollector(logger *slog.Logger) (Collector, error) {
 			"Total uncorrectable memory errors for this csrow.",
 			[]string{"controller", "csrow"}, nil,
 		),
+		dimmCECount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, edacSubsystem, "dimm_correctable_errors_total"),
+			"Total correctable memory errors for this DIMM.",
+			[]string{"controller", "dimm"}, nil,
+		),
+		dimmUECount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, edacSubsystem, "dimm_uncorrectable_errors_total"),
+			"Total uncorrectable memory errors for this DIMM.",
+			[]string{"controller", "dimm"}, nil,
+		),
+		dimmInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, edacSubsystem, "dimm_info"),
+			"Info about this DIMM, the label is the silkscreen name of its slot if known to the driver.",
+			[]string{"controller", "dimm", "label"}, nil,
+		),
 		logger: logger,
 	}, nil
 }
@@ -139,6 +160,41 @@ func (c *edacCollector) Update(ch chan<- prometheus.Metric) error {
 			ch <- prometheus.MustNewConstMetric(
 				c.csRowUECount, prometheus.CounterValue, float64(value), controllerNumber, csrowNumber)
 		}
+
+		// Newer kernels expose the DIMMs of the controller as well, older
+		// ones only the csrows.
+		dimms, err := filepath.Glob(controller + "/dimm[0-9]*")
+		if err != nil {
+			return err
+		}
+		for _, dimm := range dimms {
+			dimmMatch := edacMemDimmRE.FindStringSubmatch(dimm)
+			if dimmMatch == nil {
+				return fmt.Errorf("dimm string didn't match regexp: %s", dimm)
+			}
+			dimmNumber := dimmMatch[1]
+
+			value, err = readUintFromFile(filepath.Join(dimm, "dimm_ce_count"))
+			if err != nil {
+				return fmt.Errorf("couldn't get dimm_ce_count for controller/dimm %s/%s: %w", controllerNumber, dimmNumber, err)
+			}
+			ch <- prometheus.MustNewConstMetric(
+				c.dimmCECount, prometheus.CounterValue, float64(value), controllerNumber, dimmNumber)
+
+			value, err = readUintFromFile(filepath.Join(dimm, "dimm_ue_count"))
+			if err != nil {
+				return fmt.Errorf("couldn't get dimm_ue_count for controller/dimm %s/%s: %w", controllerNumber, dimmNumber, err)
+			}
+			ch <- prometheus.MustNewConstMetric(
+				c.dimmUECount, prometheus.CounterValue, float64(value), controllerNumber, dimmNumber)
+
+			label, err := os.ReadFile(filepath.Join(dimm, "dimm_label"))
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("couldn't get dimm_label for controller/dimm %s/%s: %w", controllerNumber, dimmNumber, err)
+			}
+			ch <- prometheus.MustNewConstMetric(
+				c.dimmInfo, prometheus.GaugeValue, 1, controllerNumber, dimmNumber, strings.TrimSpace(string(label)))
+		}
 	}
 
 	return err
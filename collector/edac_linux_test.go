@@ -0,0 +1,105 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noedac
+// +build !noedac
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testEdacCollector struct {
+	ec Collector
+}
+
+func (c testEdacCollector) Collect(ch chan<- prometheus.Metric) {
+	c.ec.Update(ch)
+}
+
+func (c testEdacCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestEdac(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		sysfs string
+		want  string
+	}{
+		{
+			// mc0 only has the legacy csrow layout, mc1 the dimm one.
+			name:  "csrow and dimm layouts",
+			sysfs: "fixtures/sys",
+			want: `# HELP node_edac_correctable_errors_total Total correctable memory errors.
+				# TYPE node_edac_correctable_errors_total counter
+				node_edac_correctable_errors_total{controller="0"} 1
+				node_edac_correctable_errors_total{controller="1"} 10
+				# HELP node_edac_csrow_correctable_errors_total Total correctable memory errors for this csrow.
+				# TYPE node_edac_csrow_correctable_errors_total counter
+				node_edac_csrow_correctable_errors_total{controller="0",csrow="0"} 3
+				node_edac_csrow_correctable_errors_total{controller="0",csrow="unknown"} 2
+				node_edac_csrow_correctable_errors_total{controller="1",csrow="unknown"} 0
+				# HELP node_edac_csrow_uncorrectable_errors_total Total uncorrectable memory errors for this csrow.
+				# TYPE node_edac_csrow_uncorrectable_errors_total counter
+				node_edac_csrow_uncorrectable_errors_total{controller="0",csrow="0"} 4
+				node_edac_csrow_uncorrectable_errors_total{controller="0",csrow="unknown"} 6
+				node_edac_csrow_uncorrectable_errors_total{controller="1",csrow="unknown"} 0
+				# HELP node_edac_dimm_correctable_errors_total Total correctable memory errors for this DIMM.
+				# TYPE node_edac_dimm_correctable_errors_total counter
+				node_edac_dimm_correctable_errors_total{controller="1",dimm="0"} 7
+				node_edac_dimm_correctable_errors_total{controller="1",dimm="1"} 3
+				# HELP node_edac_dimm_info Info about this DIMM, the label is the silkscreen name of its slot if known to the driver.
+				# TYPE node_edac_dimm_info gauge
+				node_edac_dimm_info{controller="1",dimm="0",label="CPU_SrcID#0_Ha#0_Chan#0_DIMM#0"} 1
+				node_edac_dimm_info{controller="1",dimm="1",label="CPU_SrcID#0_Ha#0_Chan#1_DIMM#0"} 1
+				# HELP node_edac_dimm_uncorrectable_errors_total Total uncorrectable memory errors for this DIMM.
+				# TYPE node_edac_dimm_uncorrectable_errors_total counter
+				node_edac_dimm_uncorrectable_errors_total{controller="1",dimm="0"} 0
+				node_edac_dimm_uncorrectable_errors_total{controller="1",dimm="1"} 1
+				# HELP node_edac_uncorrectable_errors_total Total uncorrectable memory errors.
+				# TYPE node_edac_uncorrectable_errors_total counter
+				node_edac_uncorrectable_errors_total{controller="0"} 5
+				node_edac_uncorrectable_errors_total{controller="1"} 1
+`,
+		},
+		{
+			name:  "no edac driver",
+			sysfs: t.TempDir(),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", test.sysfs}); err != nil {
+				t.Fatal(err)
+			}
+			ec, err := NewEdacCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ec.Update(make(chan prometheus.Metric, 100)); err != nil {
+				t.Fatal(err)
+			}
+			if err := testutil.CollectAndCompare(testEdacCollector{ec}, strings.NewReader(test.want)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
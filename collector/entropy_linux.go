@@ -17,8 +17,11 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs"
@@ -28,6 +31,8 @@ type entropyCollector struct {
 	fs              procfs.FS
 	entropyAvail    *prometheus.Desc
 	entropyPoolSize *prometheus.Desc
+	hwrngAvailable  *prometheus.Desc
+	hwrngCurrent    *prometheus.Desc
 	logger          *slog.Logger
 }
 
@@ -54,6 +59,16 @@ func NewEntropyCollector(logger *slog.Logger) (Collector, error) {
 			"Bits of entropy pool.",
 			nil, nil,
 		),
+		hwrngAvailable: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "entropy", "hwrng_info"),
+			"Hardware random number generators available to the kernel.",
+			[]string{"rng"}, nil,
+		),
+		hwrngCurrent: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "entropy", "hwrng_current_info"),
+			"Hardware random number generator currently used by the kernel.",
+			[]string{"rng"}, nil,
+		),
 		logger: logger,
 	}, nil
 }
@@ -64,17 +79,46 @@ func (c *entropyCollector) Update(ch chan<- prometheus.Metric) error {
 		return fmt.Errorf("failed to get kernel random stats: %w", err)
 	}
 
-	if stats.EntropyAvaliable == nil {
-		return fmt.Errorf("couldn't get entropy_avail")
+	// /proc/sys/kernel/random is missing in some sandboxed runtimes, e.g.
+	// gVisor.
+	if stats.EntropyAvaliable == nil && stats.PoolSize == nil {
+		c.logger.Debug("kernel random stats not available")
+		return ErrNoData
+	}
+	if stats.EntropyAvaliable != nil {
+		ch <- prometheus.MustNewConstMetric(
+			c.entropyAvail, prometheus.GaugeValue, float64(*stats.EntropyAvaliable))
+	}
+	if stats.PoolSize != nil {
+		ch <- prometheus.MustNewConstMetric(
+			c.entropyPoolSize, prometheus.GaugeValue, float64(*stats.PoolSize))
+	}
+
+	return c.updateHWRNG(ch)
+}
+
+// updateHWRNG exports the hardware RNGs of the hw_random driver, if it is
+// loaded.
+func (c *entropyCollector) updateHWRNG(ch chan<- prometheus.Metric) error {
+	available, err := os.ReadFile(sysFilePath("class/misc/hw_random/rng_available"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			c.logger.Debug("hw_random not available", "err", err)
+			return nil
+		}
+		return fmt.Errorf("couldn't get available hwrngs: %w", err)
+	}
+	for _, rng := range strings.Fields(string(available)) {
+		ch <- prometheus.MustNewConstMetric(c.hwrngAvailable, prometheus.GaugeValue, 1, rng)
 	}
-	ch <- prometheus.MustNewConstMetric(
-		c.entropyAvail, prometheus.GaugeValue, float64(*stats.EntropyAvaliable))
 
-	if stats.PoolSize == nil {
-		return fmt.Errorf("couldn't get entropy poolsize")
+	current, err := os.ReadFile(sysFilePath("class/misc/hw_random/rng_current"))
+	if err != nil {
+		return fmt.Errorf("couldn't get current hwrng: %w", err)
+	}
+	if rng := strings.TrimSpace(string(current)); rng != "" && rng != "none" {
+		ch <- prometheus.MustNewConstMetric(c.hwrngCurrent, prometheus.GaugeValue, 1, rng)
 	}
-	ch <- prometheus.MustNewConstMetric(
-		c.entropyPoolSize, prometheus.GaugeValue, float64(*stats.PoolSize))
 
 	return nil
 }
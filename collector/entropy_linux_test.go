@@ -0,0 +1,103 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noentropy
+// +build !noentropy
+
+package collector
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/procfs"
+)
+
+type testEntropyCollector struct {
+	ec Collector
+}
+
+func (c testEntropyCollector) Collect(ch chan<- prometheus.Metric) {
+	c.ec.Update(ch)
+}
+
+func (c testEntropyCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestEntropy(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		sysfs  string
+		hwrngs string
+	}{
+		{
+			name:  "hwrng",
+			sysfs: "fixtures/sys",
+			hwrngs: `# HELP node_entropy_hwrng_current_info Hardware random number generator currently used by the kernel.
+				# TYPE node_entropy_hwrng_current_info gauge
+				node_entropy_hwrng_current_info{rng="virtio_rng.0"} 1
+				# HELP node_entropy_hwrng_info Hardware random number generators available to the kernel.
+				# TYPE node_entropy_hwrng_info gauge
+				node_entropy_hwrng_info{rng="tpm-rng-0"} 1
+				node_entropy_hwrng_info{rng="virtio_rng.0"} 1
+`,
+		},
+		{
+			name:  "no hwrng",
+			sysfs: t.TempDir(),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc", "--path.sysfs", test.sysfs}); err != nil {
+				t.Fatal(err)
+			}
+			ec, err := NewEntropyCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := `# HELP node_entropy_available_bits Bits of available entropy.
+				# TYPE node_entropy_available_bits gauge
+				node_entropy_available_bits 1337
+` + test.hwrngs + `# HELP node_entropy_pool_size_bits Bits of entropy pool.
+				# TYPE node_entropy_pool_size_bits gauge
+				node_entropy_pool_size_bits 4096
+`
+			if err := testutil.CollectAndCompare(testEntropyCollector{ec}, strings.NewReader(want)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEntropyNotAvailable(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", t.TempDir(), "--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *procPath = procfs.DefaultMountPoint }()
+
+	ec, err := NewEntropyCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ec.Update(make(chan prometheus.Metric, 10)); !errors.Is(err, ErrNoData) {
+		t.Fatalf("expected ErrNoData, got %v", err)
+	}
+}
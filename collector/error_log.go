@@ -0,0 +1,86 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+var (
+	errorLogInterval = kingpin.Flag("collector.error-log-interval", "Log an error that a collector keeps returning at most once per interval, with the number of repeats. 0 logs every error.").Default("5m").Duration()
+
+	collectorErrorLogs = &errorLogLimiter{interval: errorLogInterval, last: map[string]*loggedError{}}
+)
+
+// errorLogLimiter collapses identical consecutive errors of a collector into
+// one log line per interval, as a collector failing on every scrape would
+// otherwise flood the logs.
+type errorLogLimiter struct {
+	interval *time.Duration
+
+	mtx  sync.Mutex
+	last map[string]*loggedError
+}
+
+type loggedError struct {
+	msg     string
+	logged  time.Time
+	repeats int
+}
+
+// allow reports whether the error msg of the collector should be logged at
+// now, and how often it was suppressed since it was last logged. If msg
+// replaces a previous error that was suppressed since it was last logged,
+// that error is returned as well, so that its repeats are not lost.
+func (l *errorLogLimiter) allow(collector, msg string, now time.Time) (bool, int, *loggedError) {
+	if *l.interval <= 0 {
+		return true, 0, nil
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	e, ok := l.last[collector]
+	if ok && e.msg == msg && now.Sub(e.logged) < *l.interval {
+		e.repeats++
+		return false, 0, nil
+	}
+	var (
+		repeats int
+		prev    *loggedError
+	)
+	if ok && e.msg == msg {
+		repeats = e.repeats
+	} else if ok && e.repeats > 0 {
+		prev = e
+	}
+	l.last[collector] = &loggedError{msg: msg, logged: now}
+	return true, repeats, prev
+}
+
+// reset forgets the last error of the collector, so that the next one is
+// logged right away. The error is returned if it was suppressed since it was
+// last logged.
+func (l *errorLogLimiter) reset(collector string) *loggedError {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	e, ok := l.last[collector]
+	delete(l.last, collector)
+	if !ok || e.repeats == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+	"time"
+)
+
+func TestErrorLogLimiter(t *testing.T) {
+	interval := time.Minute
+	l := &errorLogLimiter{interval: &interval, last: map[string]*loggedError{}}
+	begin := time.Unix(1700000000, 0)
+
+	for _, step := range []struct {
+		collector, msg string
+		offset         time.Duration
+		wantLog        bool
+		wantRepeats    int
+		wantPrev       string
+		wantPrevCount  int
+	}{
+		{"systemdstats", "permission denied", 0, true, 0, "", 0},
+		{"systemdstats", "permission denied", 15 * time.Second, false, 0, "", 0},
+		{"systemdstats", "permission denied", 30 * time.Second, false, 0, "", 0},
+		// Other collectors are limited separately.
+		{"cpu", "permission denied", 30 * time.Second, true, 0, "", 0},
+		{"systemdstats", "permission denied", 60 * time.Second, true, 2, "", 0},
+		// A different error is logged right away.
+		{"systemdstats", "no such file", 75 * time.Second, true, 0, "", 0},
+		{"systemdstats", "no such file", 90 * time.Second, false, 0, "", 0},
+		// The repeats of the replaced error are returned with it.
+		{"systemdstats", "permission denied", 95 * time.Second, true, 0, "no such file", 1},
+	} {
+		gotLog, gotRepeats, gotPrev := l.allow(step.collector, step.msg, begin.Add(step.offset))
+		if gotLog != step.wantLog || gotRepeats != step.wantRepeats {
+			t.Fatalf("%s at %s: got (%t, %d), want (%t, %d)", step.msg, step.offset, gotLog, gotRepeats, step.wantLog, step.wantRepeats)
+		}
+		var prevMsg string
+		var prevCount int
+		if gotPrev != nil {
+			prevMsg, prevCount = gotPrev.msg, gotPrev.repeats
+		}
+		if prevMsg != step.wantPrev || prevCount != step.wantPrevCount {
+			t.Fatalf("%s at %s: got previous error (%q, %d), want (%q, %d)", step.msg, step.offset, prevMsg, prevCount, step.wantPrev, step.wantPrevCount)
+		}
+	}
+
+	// After a successful scrape the error is logged again, and the repeats
+	// suppressed until then are returned.
+	if ok, _, _ := l.allow("systemdstats", "permission denied", begin.Add(100*time.Second)); ok {
+		t.Fatal("repeated error was logged")
+	}
+	if prev := l.reset("systemdstats"); prev == nil || prev.msg != "permission denied" || prev.repeats != 1 {
+		t.Fatalf("got suppressed error %+v on reset, want 1 repeat of permission denied", prev)
+	}
+	if ok, _, _ := l.allow("systemdstats", "no such file", begin.Add(105*time.Second)); !ok {
+		t.Fatal("error after reset was not logged")
+	}
+	if prev := l.reset("systemdstats"); prev != nil {
+		t.Fatalf("got suppressed error %+v on reset, want none", prev)
+	}
+
+	interval = 0
+	for i := 0; i < 2; i++ {
+		if ok, _, _ := l.allow("cpu", "permission denied", begin.Add(110*time.Second)); !ok {
+			t.Fatal("error not logged with rate limiting disabled")
+		}
+	}
+}
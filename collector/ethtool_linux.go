@@ -402,15 +402,7 @@ func (c *ethtoolCollector) Update(ch chan<- prometheus.Metric) error {
 			c.updatePortCapabilities(ch, "advertised", device, linkInfo.Advertising)
 			ch <- prometheus.MustNewConstMetric(c.entry("autonegotiate"), prometheus.GaugeValue, float64(linkInfo.Autoneg), device)
 		} else {
-			if errno, ok := err.(syscall.Errno); ok {
-				if err == unix.EOPNOTSUPP {
-					c.logger.Debug("ethtool link info error", "err", err, "device", device, "errno", uint(errno))
-				} else if errno != 0 {
-					c.logger.Error("ethtool link info error", "err", err, "device", device, "errno", uint(errno))
-				}
-			} else {
-				c.logger.Error("ethtool link info error", "err", err, "device", device)
-			}
+			c.logError("ethtool link info error", err, device)
 		}
 
 		drvInfo, err := c.ethtool.DriverInfo(device)
@@ -419,31 +411,13 @@ func (c *ethtoolCollector) Update(ch chan<- prometheus.Metric) error {
 			ch <- prometheus.MustNewConstMetric(c.infoDesc, prometheus.GaugeValue, 1.0,
 				drvInfo.BusInfo, device, drvInfo.Driver, drvInfo.EromVersion, drvInfo.FwVersion, drvInfo.Version)
 		} else {
-			if errno, ok := err.(syscall.Errno); ok {
-				if err == unix.EOPNOTSUPP {
-					c.logger.Debug("ethtool driver info error", "err", err, "device", device, "errno", uint(errno))
-				} else if errno != 0 {
-					c.logger.Error("ethtool driver info error", "err", err, "device", device, "errno", uint(errno))
-				}
-			} else {
-				c.logger.Error("ethtool driver info error", "err", err, "device", device)
-			}
+			c.logError("ethtool driver info error", err, device)
 		}
 
 		stats, err = c.ethtool.Stats(device)
 
-		// If Stats() returns EOPNOTSUPP it doesn't support ethtool stats. Log that only at Debug level.
-		// Otherwise log it at Error level.
 		if err != nil {
-			if errno, ok := err.(syscall.Errno); ok {
-				if err == unix.EOPNOTSUPP {
-					c.logger.Debug("ethtool stats error", "err", err, "device", device, "errno", uint(errno))
-				} else if errno != 0 {
-					c.logger.Error("ethtool stats error", "err", err, "device", device, "errno", uint(errno))
-				}
-			} else {
-				c.logger.Error("ethtool stats error", "err", err, "device", device)
-			}
+			c.logError("ethtool stats error", err, device)
 		}
 
 		if len(stats) == 0 {
@@ -499,6 +473,22 @@ func (c *ethtoolCollector) Update(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
+// logError logs an error of an ethtool request for the device. If the device
+// doesn't support the request (EOPNOTSUPP) or was removed since listing the
+// devices (ENODEV), e.g. a veth of a container, it's only logged at debug
+// level.
+func (c *ethtoolCollector) logError(msg string, err error, device string) {
+	errno, ok := err.(syscall.Errno)
+	switch {
+	case !ok:
+		c.logger.Error(msg, "err", err, "device", device)
+	case errno == unix.EOPNOTSUPP || errno == unix.ENODEV:
+		c.logger.Debug(msg, "err", err, "device", device, "errno", uint(errno))
+	case errno != 0:
+		c.logger.Error(msg, "err", err, "device", device, "errno", uint(errno))
+	}
+}
+
 func (c *ethtoolCollector) entryWithCreate(key, metricFQName string) *prometheus.Desc {
 	c.entriesMutex.Lock()
 	defer c.entriesMutex.Unlock()
@@ -18,6 +18,7 @@ package collector
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"log/slog"
@@ -403,3 +404,41 @@ node_network_supported_speed_bytes{device="eth0",duplex="half",mode="10baseT"} 1
 		t.Fatal(err)
 	}
 }
+
+// removedEthtool behaves like the ethtool ioctls for a device that was removed
+// after listing the devices.
+type removedEthtool struct{}
+
+func (removedEthtool) DriverInfo(string) (ethtool.DrvInfo, error) {
+	return ethtool.DrvInfo{}, unix.ENODEV
+}
+
+func (removedEthtool) Stats(string) (map[string]uint64, error) {
+	return nil, unix.ENODEV
+}
+
+func (removedEthtool) LinkInfo(string) (ethtool.EthtoolCmd, error) {
+	return ethtool.EthtoolCmd{}, unix.ENODEV
+}
+
+func TestEthToolCollectorRemovedDevice(t *testing.T) {
+	*sysPath = "fixtures/sys"
+
+	var logs bytes.Buffer
+	collector, err := makeEthtoolCollector(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	collector.ethtool = removedEthtool{}
+
+	ch := make(chan prometheus.Metric, 100)
+	if err := collector.Update(ch); err != nil {
+		t.Fatal(err)
+	}
+	if len(ch) != 0 {
+		t.Errorf("want no metrics, got %d", len(ch))
+	}
+	if strings.Contains(logs.String(), "level=ERROR") {
+		t.Errorf("removed devices should not be logged as errors:\n%s", logs.String())
+	}
+}
@@ -66,6 +66,9 @@ func NewFibreChannelCollector(logger *slog.Logger) (Collector, error) {
 		"rx_words_total":                 "Number of words received by host port",
 		"tx_frames_total":                "Number of frames transmitted by host port",
 		"link_failure_total":             "Number of times the host port link has failed",
+		"rx_bytes_total":                 "Number of bytes received by host port",
+		"tx_bytes_total":                 "Number of bytes transmitted by host port",
+		"speed_bytes":                    "Negotiated speed of the host port in bytes per second",
 	}
 
 	i.metricDescs = make(map[string]*prometheus.Desc)
@@ -94,6 +97,24 @@ func (c *fibrechannelCollector) pushCounter(ch chan<- prometheus.Metric, name st
 	}
 }
 
+// pushWordsAsBytes pushes a word counter in bytes, Fibre Channel words are 4
+// bytes long.
+func (c *fibrechannelCollector) pushWordsAsBytes(ch chan<- prometheus.Metric, name string, value uint64, host string) {
+	if value != maxUint64 {
+		ch <- prometheus.MustNewConstMetric(c.metricDescs[name], prometheus.CounterValue, float64(value)*4, host)
+	}
+}
+
+// parseFibreChannelSpeed parses the speed of a host port, e.g. "16 Gbit", into
+// bytes per second. Ports without link report "Unknown" or "Not Negotiated".
+func parseFibreChannelSpeed(speed string) (float64, bool) {
+	var gbit uint64
+	if _, err := fmt.Sscanf(speed, "%d Gbit", &gbit); err != nil {
+		return 0, false
+	}
+	return float64(gbit) * 1e9 / 8, true
+}
+
 func (c *fibrechannelCollector) Update(ch chan<- prometheus.Metric) error {
 	hosts, err := c.fs.FibreChannelClass()
 	if err != nil {
@@ -144,6 +165,14 @@ func (c *fibrechannelCollector) Update(ch chan<- prometheus.Metric) error {
 		c.pushCounter(ch, "loss_of_signal_total", *host.Counters.LossOfSignalCount, *host.Name)
 		c.pushCounter(ch, "nos_total", *host.Counters.NosCount, *host.Name)
 		c.pushCounter(ch, "fcp_packet_aborts_total", *host.Counters.FCPPacketAborts, *host.Name)
+		c.pushWordsAsBytes(ch, "rx_bytes_total", *host.Counters.RXWords, *host.Name)
+		c.pushWordsAsBytes(ch, "tx_bytes_total", *host.Counters.TXWords, *host.Name)
+
+		if host.Speed != nil {
+			if speed, ok := parseFibreChannelSpeed(*host.Speed); ok {
+				ch <- prometheus.MustNewConstMetric(c.metricDescs["speed_bytes"], prometheus.GaugeValue, speed, *host.Name)
+			}
+		}
 	}
 
 	return nil
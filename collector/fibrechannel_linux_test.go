@@ -0,0 +1,102 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nofibrechannel
+// +build !nofibrechannel
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testFibreChannelCollector struct {
+	fc Collector
+}
+
+func (c testFibreChannelCollector) Collect(ch chan<- prometheus.Metric) {
+	c.fc.Update(ch)
+}
+
+func (c testFibreChannelCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestFibreChannel(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	fc, err := NewFibreChannelCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// host0 doesn't support dumped_frames and host1 fcp_packet_aborts, both
+	// read 0xffffffffffffffff.
+	want := `# HELP node_fibrechannel_dumped_frames_total Number of dumped frames
+		# TYPE node_fibrechannel_dumped_frames_total counter
+		node_fibrechannel_dumped_frames_total{fc_host="host1"} 0
+		# HELP node_fibrechannel_fcp_packet_aborts_total Number of aborted packets
+		# TYPE node_fibrechannel_fcp_packet_aborts_total counter
+		node_fibrechannel_fcp_packet_aborts_total{fc_host="host0"} 19
+		# HELP node_fibrechannel_rx_bytes_total Number of bytes received by host port
+		# TYPE node_fibrechannel_rx_bytes_total counter
+		node_fibrechannel_rx_bytes_total{fc_host="host0"} 16
+		node_fibrechannel_rx_bytes_total{fc_host="host1"} 256
+		# HELP node_fibrechannel_speed_bytes Negotiated speed of the host port in bytes per second
+		# TYPE node_fibrechannel_speed_bytes gauge
+		node_fibrechannel_speed_bytes{fc_host="host0"} 2e+09
+		node_fibrechannel_speed_bytes{fc_host="host1"} 1e+09
+		# HELP node_fibrechannel_tx_bytes_total Number of bytes transmitted by host port
+		# TYPE node_fibrechannel_tx_bytes_total counter
+		node_fibrechannel_tx_bytes_total{fc_host="host0"} 24
+		node_fibrechannel_tx_bytes_total{fc_host="host1"} 384
+`
+	err = testutil.CollectAndCompare(testFibreChannelCollector{fc}, strings.NewReader(want),
+		"node_fibrechannel_dumped_frames_total",
+		"node_fibrechannel_fcp_packet_aborts_total",
+		"node_fibrechannel_rx_bytes_total",
+		"node_fibrechannel_speed_bytes",
+		"node_fibrechannel_tx_bytes_total",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseFibreChannelSpeed(t *testing.T) {
+	for speed, want := range map[string]float64{
+		"16 Gbit":        2e9,
+		"32 Gbit":        4e9,
+		"Unknown":        -1,
+		"Not Negotiated": -1,
+	} {
+		got, ok := parseFibreChannelSpeed(speed)
+		if want < 0 {
+			if ok {
+				t.Errorf("%q: expected no speed, got %v", speed, got)
+			}
+			continue
+		}
+		if !ok || got != want {
+			t.Errorf("%q: want %v, got %v", speed, want, got)
+		}
+	}
+}
@@ -77,7 +77,9 @@ func parseFileFDStats(filename string) (map[string]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	parts := bytes.Split(bytes.TrimSpace(content), []byte("\u0009"))
+	// The kernel separates the values by tabs, but some container runtimes
+	// emulating procfs use spaces.
+	parts := bytes.Fields(content)
 	if len(parts) < 3 {
 		return nil, fmt.Errorf("unexpected number of file stats in %q", filename)
 	}
@@ -16,7 +16,11 @@
 
 package collector
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestFileFDStats(t *testing.T) {
 	fileFDStats, err := parseFileFDStats("fixtures/proc/sys/fs/file-nr")
@@ -32,3 +36,27 @@ func TestFileFDStats(t *testing.T) {
 		t.Errorf("want filefd maximum %q, got %q", want, got)
 	}
 }
+
+func TestFileFDStatsSeparators(t *testing.T) {
+	for name, content := range map[string]string{
+		"tabs":   "2048\t0\t9223372036854775807\n",
+		"spaces": "2048 0 9223372036854775807\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "file-nr")
+			if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			fileFDStats, err := parseFileFDStats(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := "2048", fileFDStats["allocated"]; want != got {
+				t.Errorf("want filefd allocated %q, got %q", want, got)
+			}
+			if want, got := "9223372036854775807", fileFDStats["maximum"]; want != got {
+				t.Errorf("want filefd maximum %q, got %q", want, got)
+			}
+		})
+	}
+}
@@ -187,6 +187,11 @@ func (c *filesystemCollector) Update(ch chan<- prometheus.Metric) error {
 	if err != nil {
 		return err
 	}
+	c.updateStats(ch, stats)
+	return nil
+}
+
+func (c *filesystemCollector) updateStats(ch chan<- prometheus.Metric, stats []filesystemStats) {
 	// Make sure we expose a metric once, even if there are multiple mounts
 	seen := map[filesystemLabels]bool{}
 	for _, s := range stats {
@@ -220,14 +225,18 @@ func (c *filesystemCollector) Update(ch chan<- prometheus.Metric) error {
 			c.availDesc, prometheus.GaugeValue,
 			s.avail, s.labels.device, s.labels.mountPoint, s.labels.fsType, s.labels.deviceError,
 		)
-		ch <- prometheus.MustNewConstMetric(
-			c.filesDesc, prometheus.GaugeValue,
-			s.files, s.labels.device, s.labels.mountPoint, s.labels.fsType, s.labels.deviceError,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			c.filesFreeDesc, prometheus.GaugeValue,
-			s.filesFree, s.labels.device, s.labels.mountPoint, s.labels.fsType, s.labels.deviceError,
-		)
+		// Filesystems allocating inodes dynamically, like btrfs, report
+		// zero inodes. Exposing zeros would look like a full filesystem.
+		if s.files > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				c.filesDesc, prometheus.GaugeValue,
+				s.files, s.labels.device, s.labels.mountPoint, s.labels.fsType, s.labels.deviceError,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				c.filesFreeDesc, prometheus.GaugeValue,
+				s.filesFree, s.labels.device, s.labels.mountPoint, s.labels.fsType, s.labels.deviceError,
+			)
+		}
 		ch <- prometheus.MustNewConstMetric(
 			c.mountInfoDesc, prometheus.GaugeValue,
 			1.0, s.labels.device, s.labels.major, s.labels.minor, s.labels.mountPoint,
@@ -239,7 +248,6 @@ func (c *filesystemCollector) Update(ch chan<- prometheus.Metric) error {
 			)
 		}
 	}
-	return nil
 }
 
 func newMountPointsFilter(logger *slog.Logger) (deviceFilter, error) {
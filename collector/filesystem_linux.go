@@ -38,13 +38,16 @@ const (
 
 var mountTimeout = kingpin.Flag("collector.filesystem.mount-timeout",
 	"how long to wait for a mount to respond before marking it as stale").
-	Hidden().Default("5s").Duration()
+	Default("5s").Duration()
 var statWorkerCount = kingpin.Flag("collector.filesystem.stat-workers",
 	"how many stat calls to process simultaneously").
 	Hidden().Default("4").Int()
 var stuckMounts = make(map[string]struct{})
 var stuckMountsMtx = &sync.Mutex{}
 
+// statfs is replaced in tests.
+var statfs = unix.Statfs
+
 // GetStats returns filesystem stats.
 func (c *filesystemCollector) GetStats() ([]filesystemStats, error) {
 	mps, err := mountPointDetails(c.logger)
@@ -117,24 +120,56 @@ func (c *filesystemCollector) processStat(labels filesystemLabels) filesystemSta
 		}
 	}
 
-	success := make(chan struct{})
-	go stuckMountWatcher(labels.mountPoint, success, c.logger)
+	// statfs() on a hung mount blocks until the mount recovers, so it runs in
+	// a goroutine that is left behind on timeout. It unmarks the mount point
+	// as stuck once it returns.
+	type statResult struct {
+		buf *unix.Statfs_t
+		err error
+	}
+	result := make(chan statResult, 1)
+	go func() {
+		buf := new(unix.Statfs_t)
+		err := statfs(rootfsFilePath(labels.mountPoint), buf)
 
-	buf := new(unix.Statfs_t)
-	err := unix.Statfs(rootfsFilePath(labels.mountPoint), buf)
-	stuckMountsMtx.Lock()
-	close(success)
+		stuckMountsMtx.Lock()
+		defer stuckMountsMtx.Unlock()
+		// If the mount has been marked as stuck, unmark it and log it's recovery.
+		if _, ok := stuckMounts[labels.mountPoint]; ok {
+			c.logger.Debug("Mount point has recovered, monitoring will resume", "mountpoint", labels.mountPoint)
+			delete(stuckMounts, labels.mountPoint)
+		}
+		result <- statResult{buf, err}
+	}()
 
-	// If the mount has been marked as stuck, unmark it and log it's recovery.
-	if _, ok := stuckMounts[labels.mountPoint]; ok {
-		c.logger.Debug("Mount point has recovered, monitoring will resume", "mountpoint", labels.mountPoint)
-		delete(stuckMounts, labels.mountPoint)
+	mountCheckTimer := time.NewTimer(*mountTimeout)
+	defer mountCheckTimer.Stop()
+
+	var r statResult
+	select {
+	case r = <-result:
+	case <-mountCheckTimer.C:
+		stuckMountsMtx.Lock()
+		select {
+		case r = <-result:
+			// Success came in just after the timeout was reached, don't label the mount as stuck
+			stuckMountsMtx.Unlock()
+		default:
+			c.logger.Debug("Mount point timed out, it is being labeled as stuck and will not be monitored", "mountpoint", labels.mountPoint)
+			stuckMounts[labels.mountPoint] = struct{}{}
+			stuckMountsMtx.Unlock()
+			labels.deviceError = "mountpoint timeout"
+			return filesystemStats{
+				labels:      labels,
+				deviceError: 1,
+				ro:          ro,
+			}
+		}
 	}
-	stuckMountsMtx.Unlock()
 
-	if err != nil {
-		labels.deviceError = err.Error()
-		c.logger.Debug("Error on statfs() system call", "rootfs", rootfsFilePath(labels.mountPoint), "err", err)
+	if r.err != nil {
+		labels.deviceError = r.err.Error()
+		c.logger.Debug("Error on statfs() system call", "rootfs", rootfsFilePath(labels.mountPoint), "err", r.err)
 		return filesystemStats{
 			labels:      labels,
 			deviceError: 1,
@@ -142,6 +177,12 @@ func (c *filesystemCollector) processStat(labels filesystemLabels) filesystemSta
 		}
 	}
 
+	return statfsToFilesystemStats(labels, r.buf, ro)
+}
+
+// statfsToFilesystemStats converts the result of statfs() for the mount
+// point described by labels.
+func statfsToFilesystemStats(labels filesystemLabels, buf *unix.Statfs_t, ro float64) filesystemStats {
 	return filesystemStats{
 		labels:    labels,
 		size:      float64(buf.Blocks) * float64(buf.Bsize),
@@ -153,29 +194,6 @@ func (c *filesystemCollector) processStat(labels filesystemLabels) filesystemSta
 	}
 }
 
-// stuckMountWatcher listens on the given success channel and if the channel closes
-// then the watcher does nothing. If instead the timeout is reached, the
-// mount point that is being watched is marked as stuck.
-func stuckMountWatcher(mountPoint string, success chan struct{}, logger *slog.Logger) {
-	mountCheckTimer := time.NewTimer(*mountTimeout)
-	defer mountCheckTimer.Stop()
-	select {
-	case <-success:
-		// Success
-	case <-mountCheckTimer.C:
-		// Timed out, mark mount as stuck
-		stuckMountsMtx.Lock()
-		select {
-		case <-success:
-			// Success came in just after the timeout was reached, don't label the mount as stuck
-		default:
-			logger.Debug("Mount point timed out, it is being labeled as stuck and will not be monitored", "mountpoint", mountPoint)
-			stuckMounts[mountPoint] = struct{}{}
-		}
-		stuckMountsMtx.Unlock()
-	}
-}
-
 func mountPointDetails(logger *slog.Logger) ([]filesystemLabels, error) {
 	file, err := os.Open(procFilePath("1/mountinfo"))
 	if errors.Is(err, os.ErrNotExist) {
@@ -183,12 +201,70 @@ func mountPointDetails(logger *slog.Logger) ([]filesystemLabels, error) {
 		logger.Debug("Reading root mounts failed, falling back to self mounts", "err", err)
 		file, err = os.Open(procFilePath("self/mountinfo"))
 	}
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, os.ErrPermission) {
+			return nil, err
+		}
+		logger.Debug("Reading mountinfo failed, falling back to /etc/mtab", "err", err)
+		filesystems, mtabErr := mtabMountPointDetails()
+		if mtabErr != nil {
+			return nil, fmt.Errorf("%w, reading /etc/mtab failed: %w", err, mtabErr)
+		}
+		return filesystems, nil
+	}
+	defer file.Close()
+
+	filesystems, err := parseFilesystemLabels(file)
+	if err != nil || len(filesystems) > 0 {
+		return filesystems, err
+	}
+	logger.Debug("mountinfo is empty, falling back to /etc/mtab")
+	return mtabMountPointDetails()
+}
+
+// mtabMountPointDetails reads the mount points from /etc/mtab of the rootfs,
+// for systems where mountinfo is missing or doesn't show the mounts.
+func mtabMountPointDetails() ([]filesystemLabels, error) {
+	file, err := os.Open(rootfsFilePath("etc/mtab"))
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	return parseFilesystemLabels(file)
+	return parseMtabFilesystemLabels(file)
+}
+
+// parseMtabFilesystemLabels parses mounts in the fstab(5) format used by
+// /etc/mtab and /proc/mounts, which lacks the device numbers of mountinfo.
+func parseMtabFilesystemLabels(r io.Reader) ([]filesystemLabels, error) {
+	var filesystems []filesystemLabels
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.Fields(line)
+		if len(parts) < 4 {
+			return nil, fmt.Errorf("malformed mount point information: %q", line)
+		}
+
+		parts[1] = strings.ReplaceAll(parts[1], "\\040", " ")
+		parts[1] = strings.ReplaceAll(parts[1], "\\011", "\t")
+
+		filesystems = append(filesystems, filesystemLabels{
+			device:      parts[0],
+			mountPoint:  rootfsStripPrefix(parts[1]),
+			fsType:      parts[2],
+			options:     parts[3],
+			major:       "0",
+			minor:       "0",
+			deviceError: "",
+		})
+	}
+
+	return filesystems, scanner.Err()
 }
 
 func parseFilesystemLabels(r io.Reader) ([]filesystemLabels, error) {
@@ -17,12 +17,20 @@
 package collector
 
 import (
+	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/sys/unix"
 )
 
 func Test_parseFilesystemLabelsError(t *testing.T) {
@@ -151,3 +159,143 @@ func TestPathRootfs(t *testing.T) {
 		}
 	}
 }
+
+type testFilesystemCollector struct {
+	fc Collector
+}
+
+func (c testFilesystemCollector) Collect(ch chan<- prometheus.Metric) {
+	c.fc.Update(ch)
+}
+
+func (c testFilesystemCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestFilesystemZeroInodes(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "./fixtures_zeroinodes/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	// btrfs allocates inodes dynamically and reports f_files == 0, tmpfs
+	// reports its nr_inodes limit.
+	defer func(orig func(string, *unix.Statfs_t) error) { statfs = orig }(statfs)
+	statfs = func(path string, buf *unix.Statfs_t) error {
+		switch path {
+		case "/home":
+			*buf = unix.Statfs_t{Bsize: 4096, Blocks: 1000, Bfree: 400, Bavail: 300, Files: 0, Ffree: 0}
+		case "/run/user/1000":
+			*buf = unix.Statfs_t{Bsize: 4096, Blocks: 100, Bfree: 100, Bavail: 100, Files: 400859, Ffree: 400830}
+		default:
+			return fmt.Errorf("unexpected statfs of %s", path)
+		}
+		return nil
+	}
+	fc, err := NewFilesystemCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_filesystem_files Filesystem total file nodes.
+		# TYPE node_filesystem_files gauge
+		node_filesystem_files{device="tmpfs",device_error="",fstype="tmpfs",mountpoint="/run/user/1000"} 400859
+		# HELP node_filesystem_files_free Filesystem total free file nodes.
+		# TYPE node_filesystem_files_free gauge
+		node_filesystem_files_free{device="tmpfs",device_error="",fstype="tmpfs",mountpoint="/run/user/1000"} 400830
+		# HELP node_filesystem_size_bytes Filesystem size in bytes.
+		# TYPE node_filesystem_size_bytes gauge
+		node_filesystem_size_bytes{device="/dev/sda2",device_error="",fstype="btrfs",mountpoint="/home"} 4.096e+06
+		node_filesystem_size_bytes{device="tmpfs",device_error="",fstype="tmpfs",mountpoint="/run/user/1000"} 409600
+`
+	err = testutil.CollectAndCompare(testFilesystemCollector{fc}, strings.NewReader(want),
+		"node_filesystem_files", "node_filesystem_files_free", "node_filesystem_size_bytes")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMtabFallback(t *testing.T) {
+	rootfs := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rootfs, "etc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	mtab := fmt.Sprintf(`# mounts of a container
+/dev/sda1 %[1]s ext4 rw,relatime 0 0
+/dev/sda2 %[1]s/media/my\040volume xfs ro 0 0
+tmpfs /dev/shm tmpfs rw,nosuid,nodev 0 0
+`, rootfs)
+	if err := os.WriteFile(filepath.Join(rootfs, "etc/mtab"), []byte(mtab), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// No mountinfo at all.
+	missing := t.TempDir()
+	// Empty mountinfo.
+	empty := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(empty, "1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(empty, "1/mountinfo"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []filesystemLabels{
+		{device: "/dev/sda1", mountPoint: "/", fsType: "ext4", options: "rw,relatime", major: "0", minor: "0"},
+		{device: "/dev/sda2", mountPoint: "/media/my volume", fsType: "xfs", options: "ro", major: "0", minor: "0"},
+		{device: "tmpfs", mountPoint: "/dev/shm", fsType: "tmpfs", options: "rw,nosuid,nodev", major: "0", minor: "0"},
+	}
+	for _, procfs := range []string{missing, empty} {
+		if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", procfs, "--path.rootfs", rootfs}); err != nil {
+			t.Fatal(err)
+		}
+
+		filesystems, err := mountPointDetails(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(filesystems, expected) {
+			t.Errorf("procfs %s: want %v, got %v", procfs, expected, filesystems)
+		}
+	}
+}
+
+func TestFilesystemStuckMount(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--collector.filesystem.mount-timeout", "10ms"}); err != nil {
+		t.Fatal(err)
+	}
+	release := make(chan struct{})
+	defer func(orig func(string, *unix.Statfs_t) error) { statfs = orig }(statfs)
+	statfs = func(path string, buf *unix.Statfs_t) error {
+		if path == "/hung" {
+			<-release
+		}
+		buf.Bsize, buf.Blocks = 4096, 10
+		return nil
+	}
+	c := &filesystemCollector{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	stuck := func() bool {
+		stuckMountsMtx.Lock()
+		defer stuckMountsMtx.Unlock()
+		_, ok := stuckMounts["/hung"]
+		return ok
+	}
+
+	// The hung statfs() must not block the scrape.
+	stats := c.processStat(filesystemLabels{mountPoint: "/hung"})
+	if stats.deviceError != 1 || stats.labels.deviceError != "mountpoint timeout" {
+		t.Fatalf("want mountpoint timeout, got %+v", stats)
+	}
+	if !stuck() {
+		t.Fatal("want mount point marked as stuck")
+	}
+	if stats := c.processStat(filesystemLabels{mountPoint: "/ok"}); stats.deviceError != 0 || stats.size != 40960 {
+		t.Fatalf("want stats of responsive mount point, got %+v", stats)
+	}
+
+	// Once statfs() returns, the mount point is monitored again.
+	close(release)
+	for deadline := time.Now().Add(5 * time.Second); stuck(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("want mount point to recover")
+		}
+	}
+}
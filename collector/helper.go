@@ -14,6 +14,7 @@
 package collector
 
 import (
+	"fmt"
 	"os"
 	"regexp"
 	"strconv"
@@ -32,6 +33,18 @@ func readUintFromFile(path string) (uint64, error) {
 	return value, nil
 }
 
+func readIntFromFile(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
 var metricNameRegex = regexp.MustCompile(`_*[^0-9A-Za-z_]+_*`)
 
 // SanitizeMetricName sanitize the given metric name by replacing invalid characters by underscores.
@@ -48,3 +61,37 @@ var metricNameRegex = regexp.MustCompile(`_*[^0-9A-Za-z_]+_*`)
 func SanitizeMetricName(metricName string) string {
 	return metricNameRegex.ReplaceAllString(metricName, "_")
 }
+
+// countRangeList parses the list format of the kernel, e.g. "0-3,8", as used
+// by Cpus_allowed_list and smp_affinity_list, and returns the number of listed
+// values.
+func countRangeList(list string) (uint64, error) {
+	var count uint64
+	list = strings.TrimSpace(list)
+	if list == "" {
+		return 0, nil
+	}
+	for _, part := range strings.Split(list, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.ParseUint(first, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid range list %q: %w", list, err)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.ParseUint(last, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid range list %q: %w", list, err)
+			}
+			if end < start {
+				return 0, fmt.Errorf("invalid range list %q: range %q is reversed", list, part)
+			}
+		}
+		n := end - start + 1
+		if n == 0 || count+n < count {
+			return 0, fmt.Errorf("invalid range list %q: too many values", list)
+		}
+		count += n
+	}
+	return count, nil
+}
@@ -35,3 +35,41 @@ func TestSanitizeMetricName(t *testing.T) {
 		}
 	}
 }
+
+func TestCountRangeList(t *testing.T) {
+	for _, test := range []struct {
+		list    string
+		want    uint64
+		wantErr bool
+	}{
+		{list: "", want: 0},
+		{list: "5\n", want: 1},
+		{list: "0-3,8", want: 5},
+		{list: "0,2-3,10-11", want: 5},
+		{list: "3-3", want: 1},
+		{list: "0-4000000000", want: 4000000001},
+		{list: "18446744073709551615", want: 1},
+		{list: "1-18446744073709551615", want: 18446744073709551615},
+		{list: "0-18446744073709551615", wantErr: true},
+		{list: "1-18446744073709551615,0", wantErr: true},
+		{list: "3-1", wantErr: true},
+		{list: "0-", wantErr: true},
+		{list: "0,,1", wantErr: true},
+		{list: "ff", wantErr: true},
+	} {
+		got, err := countRangeList(test.list)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected error, got %d", test.list, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.list, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q: expected %d, got %d", test.list, test.want, got)
+		}
+	}
+}
@@ -36,6 +36,7 @@ var (
 	collectorHWmonChipExclude   = kingpin.Flag("collector.hwmon.chip-exclude", "Regexp of hwmon chip to exclude (mutually exclusive to device-include).").String()
 	collectorHWmonSensorInclude = kingpin.Flag("collector.hwmon.sensor-include", "Regexp of hwmon sensor to include (mutually exclusive to sensor-exclude).").String()
 	collectorHWmonSensorExclude = kingpin.Flag("collector.hwmon.sensor-exclude", "Regexp of hwmon sensor to exclude (mutually exclusive to sensor-include).").String()
+	collectorHWmonFanLabel      = kingpin.Flag("collector.hwmon.fan-sensor-label", "Use fanN_label as the sensor label of fan metrics when it is unique for the chip.").Default("false").Bool()
 
 	hwmonInvalidMetricChars = regexp.MustCompile("[^a-z0-9:_]")
 	hwmonFilenameFormat     = regexp.MustCompile(`^(?P<type>[^0-9]+)(?P<id>[0-9]*)?(_(?P<property>.+))?$`)
@@ -55,6 +56,7 @@ func init() {
 type hwMonCollector struct {
 	deviceFilter deviceFilter
 	sensorFilter deviceFilter
+	fanLabel     bool
 	logger       *slog.Logger
 }
 
@@ -66,6 +68,7 @@ func NewHwMonCollector(logger *slog.Logger) (Collector, error) {
 		logger:       logger,
 		deviceFilter: newDeviceFilter(*collectorHWmonChipExclude, *collectorHWmonChipInclude),
 		sensorFilter: newDeviceFilter(*collectorHWmonSensorExclude, *collectorHWmonSensorInclude),
+		fanLabel:     *collectorHWmonFanLabel,
 	}, nil
 }
 
@@ -206,6 +209,11 @@ func (c *hwMonCollector) updateHwmon(ch chan<- prometheus.Metric, dir string) er
 		)
 	}
 
+	var fanLabels map[string]string
+	if c.fanLabel {
+		fanLabels = hwmonFanLabels(data)
+	}
+
 	// Format all sensors.
 	for sensor, sensorData := range data {
 
@@ -227,6 +235,9 @@ func (c *hwMonCollector) updateHwmon(ch chan<- prometheus.Metric, dir string) er
 				[]string{"chip", "sensor", "label"}, nil)
 			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1.0, hwmonName, sensor, label)
 		}
+		if label, ok := fanLabels[sensor]; ok {
+			labels = []string{hwmonName, label}
+		}
 
 		if sensorType == "beep_enable" {
 			value := 0.0
@@ -351,6 +362,10 @@ func (c *hwMonCollector) updateHwmon(ch chan<- prometheus.Metric, dir string) er
 			}
 
 			if sensorType == "fan" && (element == "input" || element == "min" || element == "max" || element == "target") {
+				// Unconnected fans read 0 on many chips.
+				if element == "input" && parsedValue == 0 {
+					continue
+				}
 				desc := prometheus.NewDesc(name+"_rpm", "Hardware monitor for fan revolutions per minute ("+element+")", hwmonLabelDesc, nil)
 				ch <- prometheus.MustNewConstMetric(
 					desc, prometheus.GaugeValue, parsedValue, labels...)
@@ -377,6 +392,33 @@ func (c *hwMonCollector) updateHwmon(ch chan<- prometheus.Metric, dir string) er
 	return nil
 }
 
+// hwmonFanLabels returns the cleaned fanN_label of the fan sensors of a chip,
+// labels shared by several fans are left out to keep the series unique.
+func hwmonFanLabels(data map[string]map[string]string) map[string]string {
+	labels := map[string]string{}
+	count := map[string]int{}
+	for sensor, sensorData := range data {
+		_, sensorType, _, _ := explodeSensorFilename(sensor)
+		if sensorType != "fan" {
+			continue
+		}
+		label := cleanMetricName(sensorData["label"])
+		if label == "" {
+			// Fans without label keep their sensor name.
+			count[sensor]++
+			continue
+		}
+		labels[sensor] = label
+		count[label]++
+	}
+	for sensor, label := range labels {
+		if count[label] > 1 {
+			delete(labels, sensor)
+		}
+	}
+	return labels
+}
+
 func (c *hwMonCollector) hwmonName(dir string) (string, error) {
 	// generate a name for a sensor path
 
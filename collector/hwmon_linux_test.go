@@ -0,0 +1,182 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nohwmon
+// +build !nohwmon
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testHwMonCollector struct {
+	hc Collector
+}
+
+func (c testHwMonCollector) Collect(ch chan<- prometheus.Metric) {
+	c.hc.Update(ch)
+}
+
+func (c testHwMonCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestHwMon(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	hc, err := NewHwMonCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The fixtures hold two coretemp chips, an nct6779 (nct6775 driver), an
+	// applesmc and a chip without device link.
+	// applesmc fan1 reads 0 rpm and is left out of node_hwmon_fan_rpm.
+	want := `
+		# HELP node_hwmon_fan_alarm Hardware sensor alarm status (fan)
+		# TYPE node_hwmon_fan_alarm gauge
+		node_hwmon_fan_alarm{chip="nct6779",sensor="fan2"} 0
+		# HELP node_hwmon_fan_max_rpm Hardware monitor for fan revolutions per minute (max)
+		# TYPE node_hwmon_fan_max_rpm gauge
+		node_hwmon_fan_max_rpm{chip="platform_applesmc_768",sensor="fan1"} 6156
+		node_hwmon_fan_max_rpm{chip="platform_applesmc_768",sensor="fan2"} 5700
+		# HELP node_hwmon_fan_min_rpm Hardware monitor for fan revolutions per minute (min)
+		# TYPE node_hwmon_fan_min_rpm gauge
+		node_hwmon_fan_min_rpm{chip="nct6779",sensor="fan2"} 0
+		node_hwmon_fan_min_rpm{chip="platform_applesmc_768",sensor="fan1"} 2160
+		node_hwmon_fan_min_rpm{chip="platform_applesmc_768",sensor="fan2"} 2000
+		# HELP node_hwmon_fan_rpm Hardware monitor for fan revolutions per minute (input)
+		# TYPE node_hwmon_fan_rpm gauge
+		node_hwmon_fan_rpm{chip="nct6779",sensor="fan2"} 1098
+		node_hwmon_fan_rpm{chip="platform_applesmc_768",sensor="fan2"} 1998
+		# HELP node_hwmon_in_volts Hardware monitor for voltage (input)
+		# TYPE node_hwmon_in_volts gauge
+		node_hwmon_in_volts{chip="nct6779",sensor="in0"} 0.792
+		node_hwmon_in_volts{chip="nct6779",sensor="in1"} 1.024
+		# HELP node_hwmon_sensor_label Label for given chip and sensor
+		# TYPE node_hwmon_sensor_label gauge
+		node_hwmon_sensor_label{chip="hwmon4",label="foosensor",sensor="temp1"} 1
+		node_hwmon_sensor_label{chip="hwmon4",label="foosensor",sensor="temp2"} 1
+		node_hwmon_sensor_label{chip="hwmon4",label="mclk",sensor="freq2"} 1
+		node_hwmon_sensor_label{chip="hwmon4",label="sclk",sensor="freq1"} 1
+		node_hwmon_sensor_label{chip="platform_applesmc_768",label="Left side",sensor="fan1"} 1
+		node_hwmon_sensor_label{chip="platform_applesmc_768",label="Right side",sensor="fan2"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_0",label="Core 0",sensor="temp2"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_0",label="Core 1",sensor="temp3"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_0",label="Core 2",sensor="temp4"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_0",label="Core 3",sensor="temp5"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_0",label="Physical id 0",sensor="temp1"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_1",label="Core 0",sensor="temp2"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_1",label="Core 1",sensor="temp3"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_1",label="Core 2",sensor="temp4"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_1",label="Core 3",sensor="temp5"} 1
+		node_hwmon_sensor_label{chip="platform_coretemp_1",label="Physical id 0",sensor="temp1"} 1
+		# HELP node_hwmon_temp_celsius Hardware monitor for temperature (input)
+		# TYPE node_hwmon_temp_celsius gauge
+		node_hwmon_temp_celsius{chip="hwmon4",sensor="temp1"} 55
+		node_hwmon_temp_celsius{chip="hwmon4",sensor="temp2"} 54
+		node_hwmon_temp_celsius{chip="platform_coretemp_0",sensor="temp1"} 55
+		node_hwmon_temp_celsius{chip="platform_coretemp_0",sensor="temp2"} 54
+		node_hwmon_temp_celsius{chip="platform_coretemp_0",sensor="temp3"} 52
+		node_hwmon_temp_celsius{chip="platform_coretemp_0",sensor="temp4"} 53
+		node_hwmon_temp_celsius{chip="platform_coretemp_0",sensor="temp5"} 50
+		node_hwmon_temp_celsius{chip="platform_coretemp_1",sensor="temp1"} 55
+		node_hwmon_temp_celsius{chip="platform_coretemp_1",sensor="temp2"} 54
+		node_hwmon_temp_celsius{chip="platform_coretemp_1",sensor="temp3"} 52
+		node_hwmon_temp_celsius{chip="platform_coretemp_1",sensor="temp4"} 53
+		node_hwmon_temp_celsius{chip="platform_coretemp_1",sensor="temp5"} 50
+		# HELP node_hwmon_temp_crit_celsius Hardware monitor for temperature (crit)
+		# TYPE node_hwmon_temp_crit_celsius gauge
+		node_hwmon_temp_crit_celsius{chip="hwmon4",sensor="temp1"} 100
+		node_hwmon_temp_crit_celsius{chip="hwmon4",sensor="temp2"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_0",sensor="temp1"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_0",sensor="temp2"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_0",sensor="temp3"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_0",sensor="temp4"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_0",sensor="temp5"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_1",sensor="temp1"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_1",sensor="temp2"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_1",sensor="temp3"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_1",sensor="temp4"} 100
+		node_hwmon_temp_crit_celsius{chip="platform_coretemp_1",sensor="temp5"} 100
+`
+	err = testutil.CollectAndCompare(testHwMonCollector{hc}, strings.NewReader(want),
+		"node_hwmon_fan_alarm",
+		"node_hwmon_fan_max_rpm",
+		"node_hwmon_fan_min_rpm",
+		"node_hwmon_fan_rpm",
+		"node_hwmon_in_volts",
+		"node_hwmon_sensor_label",
+		"node_hwmon_temp_celsius",
+		"node_hwmon_temp_crit_celsius",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHwMonFanSensorLabel(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys", "--collector.hwmon.fan-sensor-label"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *collectorHWmonFanLabel = false }()
+
+	hc, err := NewHwMonCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The nct6779 fan has no label and keeps its sensor name.
+	want := `
+		# HELP node_hwmon_fan_max_rpm Hardware monitor for fan revolutions per minute (max)
+		# TYPE node_hwmon_fan_max_rpm gauge
+		node_hwmon_fan_max_rpm{chip="platform_applesmc_768",sensor="left_side"} 6156
+		node_hwmon_fan_max_rpm{chip="platform_applesmc_768",sensor="right_side"} 5700
+		# HELP node_hwmon_fan_rpm Hardware monitor for fan revolutions per minute (input)
+		# TYPE node_hwmon_fan_rpm gauge
+		node_hwmon_fan_rpm{chip="nct6779",sensor="fan2"} 1098
+		node_hwmon_fan_rpm{chip="platform_applesmc_768",sensor="right_side"} 1998
+`
+	err = testutil.CollectAndCompare(testHwMonCollector{hc}, strings.NewReader(want),
+		"node_hwmon_fan_max_rpm",
+		"node_hwmon_fan_rpm",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHwMonFanLabels(t *testing.T) {
+	data := map[string]map[string]string{
+		"fan1":  {"input": "1200", "label": "CPU Fan"},
+		"fan2":  {"input": "800", "label": "Chassis"},
+		"fan3":  {"input": "800", "label": "Chassis"},
+		"fan4":  {"input": "900", "label": "fan5"},
+		"fan5":  {"input": "900"},
+		"temp1": {"input": "40000", "label": "CPU"},
+	}
+	want := map[string]string{"fan1": "cpu_fan"}
+	if got := hwmonFanLabels(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
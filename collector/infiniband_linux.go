@@ -30,6 +30,7 @@ import (
 type infinibandCollector struct {
 	fs          sysfs.FS
 	metricDescs map[string]*prometheus.Desc
+	portInfo    *prometheus.Desc
 	logger      *slog.Logger
 	subsystem   string
 }
@@ -98,9 +99,27 @@ func NewInfiniBandCollector(logger *slog.Logger) (Collector, error) {
 		)
 	}
 
+	i.portInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, i.subsystem, "port_info"),
+		"Non-numeric data from /sys/class/infiniband/<device>/ports/<port>, value is always 1.",
+		[]string{"device", "port", "state", "physical_state"},
+		nil,
+	)
+
 	return &i, nil
 }
 
+// extendedCounter returns the 64-bit counter from counters_ext if the device
+// has it, as the corresponding counter in counters saturates at 32 bits on
+// older HCAs. Some drivers leave counters_ext at zero and already report 64
+// bits in counters, so the larger of both is used.
+func extendedCounter(extended, counter *uint64) *uint64 {
+	if extended == nil || (counter != nil && *counter > *extended) {
+		return counter
+	}
+	return extended
+}
+
 func (c *infinibandCollector) pushMetric(ch chan<- prometheus.Metric, name string, value uint64, deviceName string, port string, valueType prometheus.ValueType) {
 	ch <- prometheus.MustNewConstMetric(c.metricDescs[name], valueType, float64(value), deviceName, port)
 }
@@ -137,6 +156,7 @@ func (c *infinibandCollector) Update(ch chan<- prometheus.Metric) error {
 			c.pushMetric(ch, "state_id", uint64(port.StateID), port.Name, portStr, prometheus.GaugeValue)
 			c.pushMetric(ch, "physical_state_id", uint64(port.PhysStateID), port.Name, portStr, prometheus.GaugeValue)
 			c.pushMetric(ch, "rate_bytes_per_second", port.Rate, port.Name, portStr, prometheus.GaugeValue)
+			ch <- prometheus.MustNewConstMetric(c.portInfo, prometheus.GaugeValue, 1, port.Name, portStr, port.State, port.PhysState)
 
 			c.pushCounter(ch, "legacy_multicast_packets_received_total", port.Counters.LegacyPortMulticastRcvPackets, port.Name, portStr)
 			c.pushCounter(ch, "legacy_multicast_packets_transmitted_total", port.Counters.LegacyPortMulticastXmitPackets, port.Name, portStr)
@@ -150,20 +170,20 @@ func (c *infinibandCollector) Update(ch chan<- prometheus.Metric) error {
 			c.pushCounter(ch, "link_downed_total", port.Counters.LinkDowned, port.Name, portStr)
 			c.pushCounter(ch, "link_error_recovery_total", port.Counters.LinkErrorRecovery, port.Name, portStr)
 			c.pushCounter(ch, "local_link_integrity_errors_total", port.Counters.LocalLinkIntegrityErrors, port.Name, portStr)
-			c.pushCounter(ch, "multicast_packets_received_total", port.Counters.MulticastRcvPackets, port.Name, portStr)
-			c.pushCounter(ch, "multicast_packets_transmitted_total", port.Counters.MulticastXmitPackets, port.Name, portStr)
+			c.pushCounter(ch, "multicast_packets_received_total", extendedCounter(port.Counters.LegacyPortMulticastRcvPackets, port.Counters.MulticastRcvPackets), port.Name, portStr)
+			c.pushCounter(ch, "multicast_packets_transmitted_total", extendedCounter(port.Counters.LegacyPortMulticastXmitPackets, port.Counters.MulticastXmitPackets), port.Name, portStr)
 			c.pushCounter(ch, "port_constraint_errors_received_total", port.Counters.PortRcvConstraintErrors, port.Name, portStr)
 			c.pushCounter(ch, "port_constraint_errors_transmitted_total", port.Counters.PortXmitConstraintErrors, port.Name, portStr)
-			c.pushCounter(ch, "port_data_received_bytes_total", port.Counters.PortRcvData, port.Name, portStr)
-			c.pushCounter(ch, "port_data_transmitted_bytes_total", port.Counters.PortXmitData, port.Name, portStr)
+			c.pushCounter(ch, "port_data_received_bytes_total", extendedCounter(port.Counters.LegacyPortRcvData64, port.Counters.PortRcvData), port.Name, portStr)
+			c.pushCounter(ch, "port_data_transmitted_bytes_total", extendedCounter(port.Counters.LegacyPortXmitData64, port.Counters.PortXmitData), port.Name, portStr)
 			c.pushCounter(ch, "port_discards_received_total", port.Counters.PortRcvDiscards, port.Name, portStr)
 			c.pushCounter(ch, "port_discards_transmitted_total", port.Counters.PortXmitDiscards, port.Name, portStr)
 			c.pushCounter(ch, "port_errors_received_total", port.Counters.PortRcvErrors, port.Name, portStr)
-			c.pushCounter(ch, "port_packets_received_total", port.Counters.PortRcvPackets, port.Name, portStr)
-			c.pushCounter(ch, "port_packets_transmitted_total", port.Counters.PortXmitPackets, port.Name, portStr)
+			c.pushCounter(ch, "port_packets_received_total", extendedCounter(port.Counters.LegacyPortRcvPackets64, port.Counters.PortRcvPackets), port.Name, portStr)
+			c.pushCounter(ch, "port_packets_transmitted_total", extendedCounter(port.Counters.LegacyPortXmitPackets64, port.Counters.PortXmitPackets), port.Name, portStr)
 			c.pushCounter(ch, "port_transmit_wait_total", port.Counters.PortXmitWait, port.Name, portStr)
-			c.pushCounter(ch, "unicast_packets_received_total", port.Counters.UnicastRcvPackets, port.Name, portStr)
-			c.pushCounter(ch, "unicast_packets_transmitted_total", port.Counters.UnicastXmitPackets, port.Name, portStr)
+			c.pushCounter(ch, "unicast_packets_received_total", extendedCounter(port.Counters.LegacyPortUnicastRcvPackets, port.Counters.UnicastRcvPackets), port.Name, portStr)
+			c.pushCounter(ch, "unicast_packets_transmitted_total", extendedCounter(port.Counters.LegacyPortUnicastXmitPackets, port.Counters.UnicastXmitPackets), port.Name, portStr)
 			c.pushCounter(ch, "port_receive_remote_physical_errors_total", port.Counters.PortRcvRemotePhysicalErrors, port.Name, portStr)
 			c.pushCounter(ch, "port_receive_switch_relay_errors_total", port.Counters.PortRcvSwitchRelayErrors, port.Name, portStr)
 			c.pushCounter(ch, "symbol_error_total", port.Counters.SymbolError, port.Name, portStr)
@@ -0,0 +1,72 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noinfiniband
+// +build !noinfiniband
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testInfiniBandCollector struct {
+	ic Collector
+}
+
+func (c testInfiniBandCollector) Collect(ch chan<- prometheus.Metric) {
+	c.ic.Update(ch)
+}
+
+func (c testInfiniBandCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestInfiniBand(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	ic, err := NewInfiniBandCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// port_rcv_data of mlx4_0 port 2 lags behind port_rcv_data_64, which is
+	// used instead. Both are in units of 4 octets.
+	want := `# HELP node_infiniband_port_data_received_bytes_total Number of data octets received on all links
+		# TYPE node_infiniband_port_data_received_bytes_total counter
+		node_infiniband_port_data_received_bytes_total{device="mlx4_0",port="1"} 1.8527668e+07
+		node_infiniband_port_data_received_bytes_total{device="mlx4_0",port="2"} 1.8527668e+07
+		# HELP node_infiniband_port_info Non-numeric data from /sys/class/infiniband/<device>/ports/<port>, value is always 1.
+		# TYPE node_infiniband_port_info gauge
+		node_infiniband_port_info{device="i40iw0",physical_state="LinkUp",port="1",state="ACTIVE"} 1
+		node_infiniband_port_info{device="mlx4_0",physical_state="LinkUp",port="1",state="ACTIVE"} 1
+		node_infiniband_port_info{device="mlx4_0",physical_state="LinkUp",port="2",state="ACTIVE"} 1
+		# HELP node_infiniband_rate_bytes_per_second Maximum signal transfer rate
+		# TYPE node_infiniband_rate_bytes_per_second gauge
+		node_infiniband_rate_bytes_per_second{device="i40iw0",port="1"} 1.25e+09
+		node_infiniband_rate_bytes_per_second{device="mlx4_0",port="1"} 5e+09
+		node_infiniband_rate_bytes_per_second{device="mlx4_0",port="2"} 5e+09
+`
+	if err := testutil.CollectAndCompare(testInfiniBandCollector{ic}, strings.NewReader(want),
+		"node_infiniband_port_data_received_bytes_total", "node_infiniband_port_info", "node_infiniband_rate_bytes_per_second"); err != nil {
+		t.Fatal(err)
+	}
+}
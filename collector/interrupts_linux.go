@@ -93,17 +93,23 @@ func parseInterrupts(r io.Reader) (map[string]interrupt, error) {
 		if len(group) > 1 {
 			parts := strings.Fields(group[1])
 
-			if len(parts) < cpuNum+1 { // irq + one column per cpu + details,
+			intName := strings.TrimLeft(group[0], " ")
+			_, err := strconv.Atoi(intName)
+			numeral := err == nil
+			// irq + one column per cpu + details, which numeral
+			// interrupts without a chip name lack.
+			if len(parts) < cpuNum+1 && (!numeral || len(parts) < cpuNum) {
 				continue // we ignore ERR and MIS for now
 			}
-			intName := strings.TrimLeft(group[0], " ")
 			intr := interrupt{
 				values: parts[0:cpuNum],
 			}
 
-			if _, err := strconv.Atoi(intName); err == nil { // numeral interrupt
-				intr.info = parts[cpuNum]
-				intr.devices = strings.Join(parts[cpuNum+1:], " ")
+			if numeral {
+				if len(parts) > cpuNum {
+					intr.info = parts[cpuNum]
+					intr.devices = strings.Join(parts[cpuNum+1:], " ")
+				}
 			} else {
 				intr.info = strings.Join(parts[cpuNum:], " ")
 			}
@@ -17,8 +17,15 @@
 package collector
 
 import (
+	"io"
+	"log/slog"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestInterrupts(t *testing.T) {
@@ -70,3 +77,65 @@ func TestInterruptsArm(t *testing.T) {
 		t.Errorf("IPI0 label not found in interrupts")
 	}
 }
+
+func TestInterruptsEmptyColumns(t *testing.T) {
+	interrupts, err := parseInterrupts(strings.NewReader(`           CPU0       CPU1
+ 24:          3          4  PCI-MSI 65536-edge
+ 25:          5          6
+LOC:        100        200   Local timer interrupts
+ERR:          0
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]interrupt{
+		"24":  {info: "PCI-MSI", devices: "65536-edge", values: []string{"3", "4"}},
+		"25":  {values: []string{"5", "6"}},
+		"LOC": {info: "Local timer interrupts", values: []string{"100", "200"}},
+	} {
+		got, ok := interrupts[name]
+		if !ok {
+			t.Errorf("interrupt %s not found", name)
+			continue
+		}
+		if want.info != got.info || want.devices != got.devices || strings.Join(want.values, " ") != strings.Join(got.values, " ") {
+			t.Errorf("want interrupt %s %+v, got %+v", name, want, got)
+		}
+	}
+	if _, ok := interrupts["ERR"]; ok {
+		t.Error("ERR should be ignored")
+	}
+}
+
+type testInterruptsSumCollector struct {
+	ic Collector
+}
+
+func (c testInterruptsSumCollector) Collect(ch chan<- prometheus.Metric) {
+	c.ic.Update(ch)
+}
+
+func (c testInterruptsSumCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestInterruptsSum(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc", "--collector.interrupts.name-include", "^(1|NMI);"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *interruptsInclude = "" }()
+	c, err := NewInterruptsSumCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_interrupts_sum_total Interrupt details, summed over all CPUs.
+		# TYPE node_interrupts_sum_total counter
+		node_interrupts_sum_total{devices="",info="Non-maskable interrupts",type="NMI"} 16257
+		node_interrupts_sum_total{devices="i8042",info="IR-IO-APIC-edge",type="1"} 18121
+`
+	if err := testutil.CollectAndCompare(testInterruptsSumCollector{c}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nointerrupts
+// +build !nointerrupts
+
+package collector
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// interruptsSumCollector exports the interrupts of /proc/interrupts summed
+// over all CPUs, as the per-CPU series of the interrupts collector grow with
+// the number of CPUs.
+type interruptsSumCollector struct {
+	desc         typedDesc
+	logger       *slog.Logger
+	nameFilter   deviceFilter
+	includeZeros bool
+}
+
+func init() {
+	registerCollector("interrupts_sum", defaultEnabled, NewInterruptsSumCollector)
+}
+
+// NewInterruptsSumCollector returns a new Collector exposing interrupts
+// summed over all CPUs.
+func NewInterruptsSumCollector(logger *slog.Logger) (Collector, error) {
+	return &interruptsSumCollector{
+		desc: typedDesc{prometheus.NewDesc(
+			namespace+"_interrupts_sum_total",
+			"Interrupt details, summed over all CPUs.",
+			[]string{"type", "info", "devices"}, nil,
+		), prometheus.CounterValue},
+		logger:       logger,
+		nameFilter:   newDeviceFilter(*interruptsExclude, *interruptsInclude),
+		includeZeros: *interruptsIncludeZeros,
+	}, nil
+}
+
+func (c *interruptsSumCollector) Update(ch chan<- prometheus.Metric) error {
+	interrupts, err := getInterrupts()
+	if err != nil {
+		return fmt.Errorf("couldn't get interrupts: %w", err)
+	}
+	for name, interrupt := range interrupts {
+		filterName := name + ";" + interrupt.info + ";" + interrupt.devices
+		if c.nameFilter.ignored(filterName) {
+			c.logger.Debug("ignoring interrupt name", "filter_name", filterName)
+			continue
+		}
+		var sum float64
+		for _, value := range interrupt.values {
+			fv, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value %s in interrupts: %w", value, err)
+			}
+			sum += fv
+		}
+		if !c.includeZeros && sum == 0.0 {
+			c.logger.Debug("ignoring interrupt with zero value", "filter_name", filterName)
+			continue
+		}
+		ch <- c.desc.mustNewConstMetric(sum, name, interrupt.info, interrupt.devices)
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestIPVSCollector(t *testing.T) {
@@ -221,3 +222,44 @@ func TestIPVSCollectorResponse(t *testing.T) {
 		})
 	}
 }
+
+type testIPVSCollector struct {
+	c Collector
+}
+
+func (c testIPVSCollector) Collect(ch chan<- prometheus.Metric) {
+	c.c.Update(ch)
+}
+
+func (c testIPVSCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestIPVSCollectorAggregation(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc", "--collector.ipvs.backend-labels=proto"}); err != nil {
+		t.Fatal(err)
+	}
+	collector, err := NewIPVSCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The backends of all TCP and all firewall mark services are summed up.
+	want := `# HELP node_ipvs_backend_connections_active The current active connections by local and remote address.
+		# TYPE node_ipvs_backend_connections_active gauge
+		node_ipvs_backend_connections_active{proto="FWM"} 385
+		node_ipvs_backend_connections_active{proto="TCP"} 3741
+		# HELP node_ipvs_backend_connections_inactive The current inactive connections by local and remote address.
+		# TYPE node_ipvs_backend_connections_inactive gauge
+		node_ipvs_backend_connections_inactive{proto="FWM"} 6
+		node_ipvs_backend_connections_inactive{proto="TCP"} 5
+		# HELP node_ipvs_backend_weight The current backend weight by local and remote address.
+		# TYPE node_ipvs_backend_weight gauge
+		node_ipvs_backend_weight{proto="FWM"} 120
+		node_ipvs_backend_weight{proto="TCP"} 600
+`
+	if err := testutil.CollectAndCompare(testIPVSCollector{collector}, strings.NewReader(want),
+		"node_ipvs_backend_connections_active", "node_ipvs_backend_connections_inactive", "node_ipvs_backend_weight"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nointerrupts
+// +build !nointerrupts
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// irqAffinityCollector exports the number of CPUs each IRQ may be delivered
+// to, to find IRQs pinned to a single CPU.
+type irqAffinityCollector struct {
+	desc       typedDesc
+	logger     *slog.Logger
+	nameFilter deviceFilter
+}
+
+func init() {
+	registerCollector("irqaffinity", defaultDisabled, NewIRQAffinityCollector)
+}
+
+// NewIRQAffinityCollector returns a new Collector exposing the affinity of
+// IRQs from /proc/irq/<n>/smp_affinity_list.
+func NewIRQAffinityCollector(logger *slog.Logger) (Collector, error) {
+	return &irqAffinityCollector{
+		desc: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "irq", "affinity_cpus"),
+			"Number of CPUs the IRQ can be delivered to, from smp_affinity_list.",
+			[]string{"irq", "device"}, nil,
+		), prometheus.GaugeValue},
+		logger:     logger,
+		nameFilter: newDeviceFilter(*interruptsExclude, *interruptsInclude),
+	}, nil
+}
+
+func (c *irqAffinityCollector) Update(ch chan<- prometheus.Metric) error {
+	irqs, err := os.ReadDir(procFilePath("irq"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			c.logger.Debug("Not collecting IRQ affinity", "err", err)
+			return ErrNoData
+		}
+		return fmt.Errorf("couldn't list IRQs: %w", err)
+	}
+
+	// The devices label matches the one of the interrupts collector.
+	interrupts, err := getInterrupts()
+	if err != nil {
+		return fmt.Errorf("couldn't get interrupts: %w", err)
+	}
+
+	for _, irq := range irqs {
+		// Skip files like default_smp_affinity.
+		if _, err := strconv.Atoi(irq.Name()); err != nil || !irq.IsDir() {
+			continue
+		}
+		interrupt := interrupts[irq.Name()]
+		filterName := irq.Name() + ";" + interrupt.info + ";" + interrupt.devices
+		if c.nameFilter.ignored(filterName) {
+			c.logger.Debug("ignoring interrupt name", "filter_name", filterName)
+			continue
+		}
+
+		list, err := os.ReadFile(procFilePath(filepath.Join("irq", irq.Name(), "smp_affinity_list")))
+		if err != nil {
+			// Some IRQs, e.g. ones without an action, have no affinity.
+			if errors.Is(err, os.ErrNotExist) {
+				c.logger.Debug("IRQ has no smp_affinity_list", "irq", irq.Name())
+				continue
+			}
+			return fmt.Errorf("couldn't get affinity of IRQ %s: %w", irq.Name(), err)
+		}
+		cpus, err := countRangeList(string(list))
+		if err != nil {
+			return fmt.Errorf("invalid smp_affinity_list of IRQ %s: %w", irq.Name(), err)
+		}
+		ch <- c.desc.mustNewConstMetric(float64(cpus), irq.Name(), interrupt.devices)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nointerrupts
+// +build !nointerrupts
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testIRQAffinityCollector struct {
+	ic Collector
+}
+
+func (c testIRQAffinityCollector) Collect(ch chan<- prometheus.Metric) {
+	c.ic.Update(ch)
+}
+
+func (c testIRQAffinityCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestIRQAffinity(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	ic, err := NewIRQAffinityCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// IRQ 8 has no smp_affinity_list and IRQ 99 isn't in /proc/interrupts.
+	want := `# HELP node_irq_affinity_cpus Number of CPUs the IRQ can be delivered to, from smp_affinity_list.
+		# TYPE node_irq_affinity_cpus gauge
+		node_irq_affinity_cpus{device="",irq="99"} 1
+		node_irq_affinity_cpus{device="ehci_hcd:usb1, mmc0",irq="16"} 1
+		node_irq_affinity_cpus{device="ehci_hcd:usb2",irq="23"} 2
+		node_irq_affinity_cpus{device="i8042",irq="1"} 4
+		node_irq_affinity_cpus{device="timer",irq="0"} 4
+`
+	if err := testutil.CollectAndCompare(testIRQAffinityCollector{ic}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
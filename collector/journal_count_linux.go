@@ -0,0 +1,145 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Unlike the journal collector itself, the error counting doesn't need cgo,
+// so that it is tested in all builds.
+
+//go:build !nojournal
+// +build !nojournal
+
+package collector
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// journalMaxErrorPriority is the lowest priority counted as error, LOG_ERR.
+	journalMaxErrorPriority = 3
+
+	// Journal fields, as in sdjournal.
+	journalFieldPriority    = "PRIORITY"
+	journalFieldSystemdUnit = "_SYSTEMD_UNIT"
+
+	// journalOtherUnit is the unit label of the errors of all units over the
+	// unit limit. Unit names always have a type suffix, so it can't clash.
+	journalOtherUnit = "other"
+)
+
+// journalReader is the part of *sdjournal.Journal used to count errors.
+type journalReader interface {
+	AddMatch(match string) error
+	SeekRealtimeUsec(usec uint64) error
+	Next() (uint64, error)
+	GetRealtimeUsec() (uint64, error)
+	GetDataValue(field string) (string, error)
+}
+
+// journalErrorCounter counts the error entries of the journal by unit across
+// scrapes.
+type journalErrorCounter struct {
+	units       []string
+	maxUnits    int
+	maxLookback time.Duration
+
+	// Realtime timestamps in microseconds from and up to which entries were
+	// counted.
+	countedSince uint64
+	countedUntil uint64
+	errors       map[string]float64
+}
+
+func newJournalErrorCounter(units []string, maxUnits int, maxLookback time.Duration) *journalErrorCounter {
+	c := &journalErrorCounter{
+		units:       units,
+		maxUnits:    maxUnits,
+		maxLookback: maxLookback,
+		errors:      map[string]float64{},
+	}
+	// Export the selected units before they log their first error.
+	for _, unit := range units {
+		c.errors[unit] = 0
+	}
+	return c
+}
+
+// count counts the error entries written since the last call, but at most
+// maxLookback ago, up to now. The first call only starts the count.
+func (c *journalErrorCounter) count(j journalReader, now uint64) error {
+	if c.countedUntil == 0 {
+		c.countedSince = now
+		c.countedUntil = now
+		return nil
+	}
+	since := c.countedUntil
+	if lookback := uint64(c.maxLookback.Microseconds()); now > lookback && now-lookback > since {
+		since = now - lookback
+	}
+
+	// Matches of the same field are ORed, of different fields ANDed.
+	for priority := 0; priority <= journalMaxErrorPriority; priority++ {
+		if err := j.AddMatch(fmt.Sprintf("%s=%d", journalFieldPriority, priority)); err != nil {
+			return err
+		}
+	}
+	for _, unit := range c.units {
+		if err := j.AddMatch(journalFieldSystemdUnit + "=" + unit); err != nil {
+			return err
+		}
+	}
+	if err := j.SeekRealtimeUsec(since); err != nil {
+		return err
+	}
+
+	// Errors are only added once all entries were read, so that entries
+	// read before a failure aren't counted again by the next call.
+	counted := map[string]float64{}
+	units := len(c.errors)
+	for {
+		n, err := j.Next()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+		usec, err := j.GetRealtimeUsec()
+		if err != nil {
+			return err
+		}
+		// Entries of interleaved journal files aren't strictly ordered, so
+		// entries after one out of range may still be in range.
+		if usec < since || usec >= now {
+			continue
+		}
+		// Entries not written by a unit, e.g. of the kernel, have no unit.
+		unit, _ := j.GetDataValue(journalFieldSystemdUnit)
+		// Without selected units, any unit could log errors, so the number
+		// of series is bounded by counting new units over the limit together.
+		_, known := c.errors[unit]
+		if _, ok := counted[unit]; !known && !ok {
+			if len(c.units) == 0 && c.maxUnits > 0 && units >= c.maxUnits {
+				unit = journalOtherUnit
+			} else {
+				units++
+			}
+		}
+		counted[unit]++
+	}
+	for unit, n := range counted {
+		c.errors[unit] += n
+	}
+	c.countedUntil = now
+	return nil
+}
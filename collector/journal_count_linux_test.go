@@ -0,0 +1,227 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nojournal
+// +build !nojournal
+
+package collector
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeJournalEntry struct {
+	usec     uint64
+	priority int
+	unit     string
+}
+
+// fakeJournal filters its entries like sd-journal: matches of the same field
+// are ORed, of different fields ANDed.
+type fakeJournal struct {
+	entries []fakeJournalEntry
+	matches map[string][]string
+	pos     int
+	current *fakeJournalEntry
+	// failAt makes Next fail when it reaches the entry at this index, if
+	// positive.
+	failAt int
+}
+
+func (j *fakeJournal) AddMatch(match string) error {
+	field, value, ok := strings.Cut(match, "=")
+	if !ok {
+		return errors.New("invalid match")
+	}
+	if j.matches == nil {
+		j.matches = map[string][]string{}
+	}
+	j.matches[field] = append(j.matches[field], value)
+	return nil
+}
+
+func (j *fakeJournal) SeekRealtimeUsec(usec uint64) error {
+	j.pos = 0
+	for j.pos < len(j.entries) && j.entries[j.pos].usec < usec {
+		j.pos++
+	}
+	return nil
+}
+
+func (j *fakeJournal) matchesEntry(e fakeJournalEntry) bool {
+	fields := map[string]string{
+		journalFieldPriority:    strconv.Itoa(e.priority),
+		journalFieldSystemdUnit: e.unit,
+	}
+	for field, values := range j.matches {
+		found := false
+		for _, v := range values {
+			found = found || fields[field] == v
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *fakeJournal) Next() (uint64, error) {
+	for j.pos < len(j.entries) {
+		if j.failAt > 0 && j.pos == j.failAt {
+			return 0, errors.New("read error")
+		}
+		e := j.entries[j.pos]
+		j.pos++
+		if j.matchesEntry(e) {
+			j.current = &e
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (j *fakeJournal) GetRealtimeUsec() (uint64, error) {
+	return j.current.usec, nil
+}
+
+func (j *fakeJournal) GetDataValue(field string) (string, error) {
+	if field != journalFieldSystemdUnit || j.current.unit == "" {
+		return "", errors.New("no such field")
+	}
+	return j.current.unit, nil
+}
+
+var fakeJournalEntries = []fakeJournalEntry{
+	{usec: 1_000, priority: 3, unit: "a.service"},
+	{usec: 2_000, priority: 3, unit: "a.service"},
+	{usec: 2_100, priority: 6, unit: "a.service"},
+	{usec: 2_200, priority: 2, unit: "b.service"},
+	{usec: 2_300, priority: 3},
+	{usec: 2_400, priority: 0, unit: "c.service"},
+	{usec: 2_500, priority: 3, unit: "d.service"},
+	{usec: 5_000, priority: 3, unit: "a.service"},
+}
+
+func TestJournalErrorCounter(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		units    []string
+		maxUnits int
+		want     map[string]float64
+	}{
+		{
+			name: "all units",
+			want: map[string]float64{"a.service": 1, "b.service": 1, "": 1, "c.service": 1, "d.service": 1},
+		},
+		{
+			name:  "selected units",
+			units: []string{"a.service", "b.service", "e.service"},
+			// Units that haven't logged errors yet are exported too.
+			want: map[string]float64{"a.service": 1, "b.service": 1, "e.service": 0},
+		},
+		{
+			name:     "unit limit",
+			maxUnits: 2,
+			want:     map[string]float64{"a.service": 1, "b.service": 1, "other": 3},
+		},
+		{
+			name:     "unit limit doesn't apply to selected units",
+			units:    []string{"a.service", "c.service", "d.service"},
+			maxUnits: 1,
+			want:     map[string]float64{"a.service": 1, "c.service": 1, "d.service": 1},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			c := newJournalErrorCounter(test.units, test.maxUnits, time.Hour)
+
+			// The first scrape only starts the count, the second one
+			// counts the entries since, but not the ones from its future.
+			if err := c.count(&fakeJournal{entries: fakeJournalEntries}, 1_500); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.count(&fakeJournal{entries: fakeJournalEntries}, 3_000); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(c.errors, test.want) {
+				t.Errorf("want errors %v, got %v", test.want, c.errors)
+			}
+			// The counters were created when counting started.
+			if c.countedSince != 1_500 {
+				t.Errorf("want count started at 1500, got %d", c.countedSince)
+			}
+		})
+	}
+}
+
+func TestJournalErrorCounterMaxLookback(t *testing.T) {
+	c := newJournalErrorCounter(nil, 0, time.Millisecond)
+	if err := c.count(&fakeJournal{entries: fakeJournalEntries}, 500); err != nil {
+		t.Fatal(err)
+	}
+	// Only the entries of the last millisecond before 3000 are counted.
+	if err := c.count(&fakeJournal{entries: fakeJournalEntries}, 3_000); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"a.service": 1, "b.service": 1, "": 1, "c.service": 1, "d.service": 1}
+	if !reflect.DeepEqual(c.errors, want) {
+		t.Errorf("want errors %v, got %v", want, c.errors)
+	}
+}
+
+func TestJournalErrorCounterUnordered(t *testing.T) {
+	// An entry from after now interleaved from another journal file doesn't
+	// end the count.
+	entries := []fakeJournalEntry{
+		{usec: 2_000, priority: 3, unit: "a.service"},
+		{usec: 4_000, priority: 3, unit: "b.service"},
+		{usec: 2_500, priority: 3, unit: "c.service"},
+	}
+	c := newJournalErrorCounter(nil, 0, time.Hour)
+	if err := c.count(&fakeJournal{entries: entries}, 1_000); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.count(&fakeJournal{entries: entries}, 3_000); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"a.service": 1, "c.service": 1}
+	if !reflect.DeepEqual(c.errors, want) {
+		t.Errorf("want errors %v, got %v", want, c.errors)
+	}
+}
+
+func TestJournalErrorCounterReadError(t *testing.T) {
+	c := newJournalErrorCounter(nil, 0, time.Hour)
+	if err := c.count(&fakeJournal{entries: fakeJournalEntries}, 1_500); err != nil {
+		t.Fatal(err)
+	}
+	// The entries read before the error are neither counted now nor twice
+	// by the next call.
+	if err := c.count(&fakeJournal{entries: fakeJournalEntries, failAt: 3}, 3_000); err == nil {
+		t.Fatal("want read error")
+	}
+	if len(c.errors) != 0 {
+		t.Errorf("want no errors counted after the read error, got %v", c.errors)
+	}
+	if err := c.count(&fakeJournal{entries: fakeJournalEntries}, 3_000); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"a.service": 1, "b.service": 1, "": 1, "c.service": 1, "d.service": 1}
+	if !reflect.DeepEqual(c.errors, want) {
+		t.Errorf("want errors %v, got %v", want, c.errors)
+	}
+}
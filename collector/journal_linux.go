@@ -0,0 +1,106 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The sd-journal API needs cgo and the libsystemd headers, which the
+// release builds don't have, so this collector has to be built with
+// -tags journal.
+
+//go:build journal && cgo && !nojournal
+// +build journal,cgo,!nojournal
+
+package collector
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// journalDirs are the directories of the volatile and persistent journal,
+// opening the journal succeeds even if neither exists.
+var journalDirs = []string{"/run/log/journal", "/var/log/journal"}
+
+var (
+	journalUnits       = kingpin.Flag("collector.journal.units", "Only count journal entries of this systemd unit. (repeatable)").Strings()
+	journalMaxLookback = kingpin.Flag("collector.journal.max-lookback", "Maximum age of journal entries read in one scrape. Entries older than this since the last scrape are not counted.").Default("5m").Duration()
+	journalMaxUnits    = kingpin.Flag("collector.journal.max-units", "Maximum number of units to count errors of without --collector.journal.units, errors of further units are counted as unit \"other\". Use 0 to disable.").Default("100").Int()
+)
+
+type journalCollector struct {
+	errorEntries *prometheus.Desc
+	logger       *slog.Logger
+
+	mtx     sync.Mutex
+	counter *journalErrorCounter
+	// unavailableLogged is set once the journal couldn't be opened.
+	unavailableLogged bool
+}
+
+var _ journalReader = &sdjournal.Journal{}
+
+func init() {
+	registerCollector("journal", defaultDisabled, NewJournalCollector)
+}
+
+// NewJournalCollector returns a new Collector counting the error entries
+// written to the systemd journal.
+func NewJournalCollector(logger *slog.Logger) (Collector, error) {
+	return &journalCollector{
+		errorEntries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "journal", "error_entries_total"),
+			"Number of journal entries with priority err or higher written since the exporter started, by systemd unit.",
+			[]string{"unit"}, nil,
+		),
+		logger:  logger,
+		counter: newJournalErrorCounter(*journalUnits, *journalMaxUnits, *journalMaxLookback),
+	}, nil
+}
+
+func (c *journalCollector) Update(ch chan<- prometheus.Metric) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	j, err := openJournal()
+	if err != nil {
+		if !c.unavailableLogged {
+			c.unavailableLogged = true
+			c.logger.Warn("couldn't open the systemd journal", "err", err)
+		}
+		return ErrNoData
+	}
+	defer j.Close()
+
+	if err := c.counter.count(j, uint64(time.Now().UnixMicro())); err != nil {
+		return err
+	}
+	created := time.UnixMicro(int64(c.counter.countedSince))
+	for unit, count := range c.counter.errors {
+		ch <- prometheus.MustNewConstMetricWithCreatedTimestamp(c.errorEntries, prometheus.CounterValue, count, created, unit)
+	}
+	return nil
+}
+
+func openJournal() (*sdjournal.Journal, error) {
+	for _, dir := range journalDirs {
+		if _, err := os.Stat(dir); err == nil {
+			return sdjournal.NewJournal()
+		}
+	}
+	return nil, errors.New("no journal directory found")
+}
@@ -17,8 +17,10 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"path/filepath"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -64,9 +66,20 @@ func NewKsmdCollector(logger *slog.Logger) (Collector, error) {
 
 // Update implements Collector and exposes kernel and system statistics.
 func (c *ksmdCollector) Update(ch chan<- prometheus.Metric) error {
+	// Kernels built without CONFIG_KSM lack the directory entirely.
+	if _, err := os.Stat(sysFilePath("kernel/mm/ksm")); errors.Is(err, os.ErrNotExist) {
+		c.logger.Debug("ksm not available", "err", err)
+		return ErrNoData
+	}
+
 	for _, n := range ksmdFiles {
 		val, err := readUintFromFile(sysFilePath(filepath.Join("kernel/mm/ksm", n)))
 		if err != nil {
+			// Older kernels lack some of the files, e.g. merge_across_nodes.
+			if errors.Is(err, os.ErrNotExist) {
+				c.logger.Debug("ksmd file not available", "file", n, "err", err)
+				continue
+			}
 			return err
 		}
 
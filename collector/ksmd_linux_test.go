@@ -0,0 +1,101 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noksmd
+// +build !noksmd
+
+package collector
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testKsmdCollector struct {
+	kc Collector
+}
+
+func (c testKsmdCollector) Collect(ch chan<- prometheus.Metric) {
+	c.kc.Update(ch)
+}
+
+func (c testKsmdCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestKsmd(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	kc, err := NewKsmdCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_ksmd_full_scans_total ksmd 'full_scans' file.
+		# TYPE node_ksmd_full_scans_total counter
+		node_ksmd_full_scans_total 323
+		# HELP node_ksmd_merge_across_nodes ksmd 'merge_across_nodes' file.
+		# TYPE node_ksmd_merge_across_nodes gauge
+		node_ksmd_merge_across_nodes 1
+		# HELP node_ksmd_pages_shared ksmd 'pages_shared' file.
+		# TYPE node_ksmd_pages_shared gauge
+		node_ksmd_pages_shared 1
+		# HELP node_ksmd_pages_sharing ksmd 'pages_sharing' file.
+		# TYPE node_ksmd_pages_sharing gauge
+		node_ksmd_pages_sharing 255
+		# HELP node_ksmd_pages_to_scan ksmd 'pages_to_scan' file.
+		# TYPE node_ksmd_pages_to_scan gauge
+		node_ksmd_pages_to_scan 100
+		# HELP node_ksmd_pages_unshared ksmd 'pages_unshared' file.
+		# TYPE node_ksmd_pages_unshared gauge
+		node_ksmd_pages_unshared 0
+		# HELP node_ksmd_pages_volatile ksmd 'pages_volatile' file.
+		# TYPE node_ksmd_pages_volatile gauge
+		node_ksmd_pages_volatile 0
+		# HELP node_ksmd_run ksmd 'run' file.
+		# TYPE node_ksmd_run gauge
+		node_ksmd_run 1
+		# HELP node_ksmd_sleep_seconds ksmd 'sleep_millisecs' file.
+		# TYPE node_ksmd_sleep_seconds gauge
+		node_ksmd_sleep_seconds 0.02
+`
+	if err := testutil.CollectAndCompare(testKsmdCollector{kc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKsmdNotAvailable(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	kc, err := NewKsmdCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := make(chan prometheus.Metric, 10)
+	if err := kc.Update(ch); !errors.Is(err, ErrNoData) {
+		t.Fatalf("want ErrNoData, got %v", err)
+	}
+	if n := len(ch); n != 0 {
+		t.Errorf("want no metrics, got %d", n)
+	}
+}
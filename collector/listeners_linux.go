@@ -0,0 +1,193 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nolisteners
+// +build !nolisteners
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+)
+
+const (
+	// Socket states from include/net/tcp_states.h, tcpstat has its own
+	// copy but can be disabled at build time.
+	socketStateListen = 0x0a
+	socketStateClose  = 0x07
+)
+
+var (
+	listenersProcessCacheTTL = kingpin.Flag("collector.listeners.process-cache-ttl", "Minimum time between rescans of /proc/*/fd to resolve the processes owning new listening sockets.").Default("1m").Duration()
+)
+
+type listenersCollector struct {
+	fs       procfs.FS
+	info     *prometheus.Desc
+	cacheTTL time.Duration
+	logger   *slog.Logger
+
+	mtx sync.Mutex
+	// Name of the process owning each socket inode, as of cacheTime.
+	inodeProcesses map[uint64]string
+	cacheTime      time.Time
+}
+
+type listener struct {
+	protocol, address, port string
+	inode                   uint64
+}
+
+func init() {
+	registerCollector("listeners", defaultDisabled, NewListenersCollector)
+}
+
+// NewListenersCollector returns a new Collector exposing the listening TCP
+// and UDP sockets and the processes owning them.
+func NewListenersCollector(logger *slog.Logger) (Collector, error) {
+	fs, err := procfs.NewFS(*procPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open procfs: %w", err)
+	}
+	return &listenersCollector{
+		fs: fs,
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "network", "listener_info"),
+			"Listening TCP and unconnected UDP sockets. The process label is empty if the owning process could not be resolved.",
+			[]string{"protocol", "address", "port", "process"}, nil,
+		),
+		cacheTTL: *listenersProcessCacheTTL,
+		logger:   logger,
+	}, nil
+}
+
+func (c *listenersCollector) Update(ch chan<- prometheus.Metric) error {
+	listeners, err := c.listeners()
+	if err != nil {
+		return err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.refreshProcesses(listeners, time.Now())
+
+	// Several sockets can listen on the same address, e.g. with SO_REUSEPORT.
+	seen := map[[4]string]bool{}
+	for _, l := range listeners {
+		key := [4]string{l.protocol, l.address, l.port, c.inodeProcesses[l.inode]}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ch <- prometheus.MustNewConstMetric(c.info, prometheus.GaugeValue, 1, key[:]...)
+	}
+	return nil
+}
+
+// listeners returns the listening sockets from /proc/net/{tcp,udp}{,6}.
+func (c *listenersCollector) listeners() ([]listener, error) {
+	var listeners []listener
+	for _, t := range []struct {
+		protocol string
+		read     func() (procfs.NetIPSocket, error)
+		state    uint64
+	}{
+		{"tcp", func() (procfs.NetIPSocket, error) { s, err := c.fs.NetTCP(); return procfs.NetIPSocket(s), err }, socketStateListen},
+		{"tcp6", func() (procfs.NetIPSocket, error) { s, err := c.fs.NetTCP6(); return procfs.NetIPSocket(s), err }, socketStateListen},
+		{"udp", func() (procfs.NetIPSocket, error) { s, err := c.fs.NetUDP(); return procfs.NetIPSocket(s), err }, socketStateClose},
+		{"udp6", func() (procfs.NetIPSocket, error) { s, err := c.fs.NetUDP6(); return procfs.NetIPSocket(s), err }, socketStateClose},
+	} {
+		sockets, err := t.read()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				c.logger.Debug("not collecting listeners, protocol not available", "protocol", t.protocol)
+				continue
+			}
+			return nil, fmt.Errorf("couldn't get %s sockets: %w", t.protocol, err)
+		}
+		for _, s := range sockets {
+			// Bound UDP sockets which are not connected to a peer are in
+			// state TCP_CLOSE.
+			if s.St != t.state || (t.state == socketStateClose && s.RemPort != 0) {
+				continue
+			}
+			listeners = append(listeners, listener{
+				protocol: t.protocol,
+				address:  s.LocalAddr.String(),
+				port:     strconv.FormatUint(s.LocalPort, 10),
+				inode:    s.Inode,
+			})
+		}
+	}
+	return listeners, nil
+}
+
+// refreshProcesses rebuilds the inode to process map from /proc/*/fd if it
+// doesn't know the inode of a listener and wasn't rebuilt within cacheTTL.
+// The caller must hold mtx.
+func (c *listenersCollector) refreshProcesses(listeners []listener, now time.Time) {
+	if c.inodeProcesses != nil && now.Sub(c.cacheTime) < c.cacheTTL {
+		return
+	}
+	known := c.inodeProcesses != nil
+	for _, l := range listeners {
+		if _, ok := c.inodeProcesses[l.inode]; !ok {
+			known = false
+			break
+		}
+	}
+	if known {
+		return
+	}
+
+	procs, err := c.fs.AllProcs()
+	if err != nil {
+		c.logger.Debug("couldn't list processes", "err", err)
+		return
+	}
+	inodeProcesses := map[uint64]string{}
+	for _, p := range procs {
+		// Reading the fds of processes of other users requires privileges
+		// the exporter usually doesn't have, those sockets stay unresolved.
+		targets, err := p.FileDescriptorTargets()
+		if err != nil {
+			continue
+		}
+		comm, err := p.Comm()
+		if err != nil {
+			continue
+		}
+		for _, target := range targets {
+			inode, ok := strings.CutPrefix(target, "socket:[")
+			if !ok {
+				continue
+			}
+			if i, err := strconv.ParseUint(strings.TrimSuffix(inode, "]"), 10, 64); err == nil {
+				inodeProcesses[i] = comm
+			}
+		}
+	}
+	c.inodeProcesses = inodeProcesses
+	c.cacheTime = now
+}
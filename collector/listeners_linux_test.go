@@ -0,0 +1,109 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nolisteners
+// +build !nolisteners
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testListenersCollector struct {
+	lc Collector
+}
+
+func (c testListenersCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lc.Update(ch)
+}
+
+func (c testListenersCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+const listenersSocketHeader = "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"
+
+func TestListeners(t *testing.T) {
+	proc := t.TempDir()
+	files := map[string]string{
+		// sshd listening on 0.0.0.0:22 twice (SO_REUSEPORT), an established
+		// connection to it, and a listener on 127.0.0.1:9100 of an
+		// unresolvable process.
+		"net/tcp": listenersSocketHeader +
+			"   0: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1001 1 0000000000000000 100 0 0 10 0\n" +
+			"   1: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1002 1 0000000000000000 100 0 0 10 0\n" +
+			"   2: 0100007F:0016 0100007F:D431 01 00000000:00000000 00:00000000 00000000     0        0 1003 1 0000000000000000 20 4 30 10 -1\n" +
+			"   3: 0100007F:238C 00000000:0000 0A 00000000:00000000 00:00000000 00000000 65534        0 1004 1 0000000000000000 100 0 0 10 0\n",
+		"net/tcp6": listenersSocketHeader +
+			"   0: 00000000000000000000000000000000:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1005 1 0000000000000000 100 0 0 10 0\n",
+		// chronyd on 127.0.0.1:323 and a connected UDP socket.
+		"net/udp": listenersSocketHeader +
+			"   0: 0100007F:0143 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 1006 2 0000000000000000 0\n" +
+			"   1: 0A00000F:A1B2 08080808:0035 01 00000000:00000000 00:00000000 00000000     0        0 1007 2 0000000000000000 0\n",
+		"100/comm": "sshd\n",
+		"200/comm": "chronyd\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(proc, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for link, target := range map[string]string{
+		"100/fd/0": "/dev/null",
+		"100/fd/3": "socket:[1001]",
+		"100/fd/4": "socket:[1002]",
+		"100/fd/5": "socket:[1005]",
+		"200/fd/5": "socket:[1006]",
+	} {
+		path := filepath.Join(proc, link)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", proc}); err != nil {
+		t.Fatal(err)
+	}
+	lc, err := NewListenersCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_network_listener_info Listening TCP and unconnected UDP sockets. The process label is empty if the owning process could not be resolved.
+		# TYPE node_network_listener_info gauge
+		node_network_listener_info{address="0.0.0.0",port="22",process="sshd",protocol="tcp"} 1
+		node_network_listener_info{address="127.0.0.1",port="9100",process="",protocol="tcp"} 1
+		node_network_listener_info{address="::",port="22",process="sshd",protocol="tcp6"} 1
+		node_network_listener_info{address="127.0.0.1",port="323",process="chronyd",protocol="udp"} 1
+`
+	if err := testutil.CollectAndCompare(testListenersCollector{lc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -17,10 +17,13 @@
 package collector
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 	"github.com/prometheus/client_golang/prometheus"
@@ -39,6 +42,10 @@ var (
 	attrTypeValues   = []string{"other", "unspecified", "tty", "x11", "wayland", "mir", "web"}
 	attrClassValues  = []string{"other", "user", "greeter", "lock-screen", "background"}
 
+	// logindTimeout bounds a scrape of logind, so that an unresponsive
+	// D-Bus doesn't hang the collector.
+	logindTimeout = 5 * time.Second
+
 	sessionsDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, logindSubsystem, "sessions"),
 		"Number of sessions registered in logind.", []string{"seat", "remote", "type", "class"}, nil,
@@ -91,13 +98,32 @@ func NewLogindCollector(logger *slog.Logger) (Collector, error) {
 }
 
 func (lc *logindCollector) Update(ch chan<- prometheus.Metric) error {
-	c, err := newDbus()
+	// The connection is closed once ctx is done, which fails any pending
+	// call.
+	ctx, cancel := context.WithTimeout(context.Background(), logindTimeout)
+	defer cancel()
+
+	c, err := newDbus(ctx)
 	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("timed out connecting to dbus after %s: %w", logindTimeout, err)
+		}
 		return fmt.Errorf("unable to connect to dbus: %w", err)
 	}
 	defer c.conn.Close()
 
-	return collectMetrics(ch, c)
+	err = collectMetrics(ch, c)
+	var dbusErr dbus.Error
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil:
+		return fmt.Errorf("timed out querying logind after %s: %w", logindTimeout, err)
+	case errors.As(err, &dbusErr) && dbusErr.Name == "org.freedesktop.DBus.Error.ServiceUnknown":
+		return fmt.Errorf("logind is not running: %w", err)
+	default:
+		return err
+	}
 }
 
 func collectMetrics(ch chan<- prometheus.Metric, c logindInterface) error {
@@ -147,8 +173,8 @@ func knownStringOrOther(value string, known []string) string {
 	return "other"
 }
 
-func newDbus() (*logindDbus, error) {
-	conn, err := dbus.SystemBusPrivate()
+func newDbus(ctx context.Context) (*logindDbus, error) {
+	conn, err := dbus.SystemBusPrivate(dbus.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
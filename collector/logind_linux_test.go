@@ -17,7 +17,13 @@
 package collector
 
 import (
+	"io"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 	"github.com/prometheus/client_golang/prometheus"
@@ -103,3 +109,48 @@ func TestLogindCollectorCollectMetrics(t *testing.T) {
 		t.Errorf("collectMetrics did not generate the expected number of metrics: got %d, expected %d.", count, expected)
 	}
 }
+
+func TestLogindCollectorTimeout(t *testing.T) {
+	// A system bus which accepts connections but never answers.
+	path := filepath.Join(t.TempDir(), "system_bus_socket")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		var conns []net.Conn
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				for _, conn := range conns {
+					conn.Close()
+				}
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+	t.Setenv("DBUS_SYSTEM_BUS_ADDRESS", "unix:path="+path)
+
+	defer func(timeout time.Duration) { logindTimeout = timeout }(logindTimeout)
+	logindTimeout = 100 * time.Millisecond
+
+	c, err := NewLogindCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- c.Update(make(chan prometheus.Metric, 100))
+	}()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "timed out") {
+			t.Fatalf("want timeout error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Update didn't time out")
+	}
+}
@@ -72,6 +72,18 @@ var (
 		[]string{"device"},
 		prometheus.Labels{"state": "check"},
 	)
+	reshapeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "md", "state"),
+		"Indicates the state of md-device.",
+		[]string{"device"},
+		prometheus.Labels{"state": "reshape"},
+	)
+	degradedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "md", "state"),
+		"Indicates the state of md-device.",
+		[]string{"device"},
+		prometheus.Labels{"state": "degraded"},
+	)
 
 	disksDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "md", "disks"),
@@ -101,6 +113,20 @@ var (
 		nil,
 	)
 
+	syncCompletedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "md", "sync_completed"),
+		"Fraction (0-1) of the current sync operation that has completed.",
+		[]string{"device"},
+		nil,
+	)
+
+	syncTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "md", "sync_total"),
+		"Number of blocks to be synced by the current sync operation.",
+		[]string{"device"},
+		nil,
+	)
+
 	mdraidDisks = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "md", "raid_disks"),
 		"Number of raid disks on device.",
@@ -138,6 +164,11 @@ func (c *mdadmCollector) Update(ch chan<- prometheus.Metric) error {
 
 		stateVals := make(map[string]float64)
 		stateVals[mdStat.ActivityState] = 1
+		// An array is degraded when at least one of its members is
+		// missing, regardless of whether it is being recovered.
+		if mdStat.DisksDown > 0 {
+			stateVals["degraded"] = 1
+		}
 
 		ch <- prometheus.MustNewConstMetric(
 			disksTotalDesc,
@@ -202,6 +233,20 @@ func (c *mdadmCollector) Update(ch chan<- prometheus.Metric) error {
 			mdStat.Name,
 		)
 
+		ch <- prometheus.MustNewConstMetric(
+			reshapeDesc,
+			prometheus.GaugeValue,
+			stateVals["reshaping"],
+			mdStat.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			degradedDesc,
+			prometheus.GaugeValue,
+			stateVals["degraded"],
+			mdStat.Name,
+		)
+
 		ch <- prometheus.MustNewConstMetric(
 			blocksTotalDesc,
 			prometheus.GaugeValue,
@@ -214,6 +259,23 @@ func (c *mdadmCollector) Update(ch chan<- prometheus.Metric) error {
 			float64(mdStat.BlocksSynced),
 			mdStat.Name,
 		)
+
+		var syncCompleted float64
+		if mdStat.BlocksToBeSynced > 0 {
+			syncCompleted = float64(mdStat.BlocksSynced) / float64(mdStat.BlocksToBeSynced)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			syncCompletedDesc,
+			prometheus.GaugeValue,
+			syncCompleted,
+			mdStat.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			syncTotalDesc,
+			prometheus.GaugeValue,
+			float64(mdStat.BlocksToBeSynced),
+			mdStat.Name,
+		)
 	}
 
 	sysFS, err := sysfs.NewFS(*sysPath)
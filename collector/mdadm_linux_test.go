@@ -63,6 +63,7 @@ func TestMdadmStats(t *testing.T) {
         node_md_blocks{device="md201"} 1.993728e+06
         node_md_blocks{device="md219"} 7932
         node_md_blocks{device="md3"} 5.853468288e+09
+        node_md_blocks{device="md30"} 5.8601472e+09
         node_md_blocks{device="md4"} 4.883648e+06
         node_md_blocks{device="md6"} 1.95310144e+08
         node_md_blocks{device="md7"} 7.813735424e+09
@@ -82,6 +83,7 @@ func TestMdadmStats(t *testing.T) {
         node_md_blocks_synced{device="md201"} 114176
         node_md_blocks_synced{device="md219"} 7932
         node_md_blocks_synced{device="md3"} 5.853468288e+09
+        node_md_blocks_synced{device="md30"} 2.4425216e+08
         node_md_blocks_synced{device="md4"} 4.883648e+06
         node_md_blocks_synced{device="md6"} 1.6775552e+07
         node_md_blocks_synced{device="md7"} 7.813735424e+09
@@ -133,6 +135,9 @@ func TestMdadmStats(t *testing.T) {
         node_md_disks{device="md3",state="active"} 8
         node_md_disks{device="md3",state="failed"} 0
         node_md_disks{device="md3",state="spare"} 2
+        node_md_disks{device="md30",state="active"} 5
+        node_md_disks{device="md30",state="failed"} 0
+        node_md_disks{device="md30",state="spare"} 0
         node_md_disks{device="md4",state="active"} 0
         node_md_disks{device="md4",state="failed"} 1
         node_md_disks{device="md4",state="spare"} 1
@@ -162,6 +167,7 @@ func TestMdadmStats(t *testing.T) {
         node_md_disks_required{device="md201"} 2
         node_md_disks_required{device="md219"} 0
         node_md_disks_required{device="md3"} 8
+        node_md_disks_required{device="md30"} 5
         node_md_disks_required{device="md4"} 0
         node_md_disks_required{device="md6"} 2
         node_md_disks_required{device="md7"} 4
@@ -179,89 +185,170 @@ func TestMdadmStats(t *testing.T) {
         # TYPE node_md_state gauge
         node_md_state{device="md0",state="active"} 1
         node_md_state{device="md0",state="check"} 0
+        node_md_state{device="md0",state="degraded"} 0
         node_md_state{device="md0",state="inactive"} 0
         node_md_state{device="md0",state="recovering"} 0
+        node_md_state{device="md0",state="reshape"} 0
         node_md_state{device="md0",state="resync"} 0
         node_md_state{device="md00",state="active"} 1
         node_md_state{device="md00",state="check"} 0
+        node_md_state{device="md00",state="degraded"} 0
         node_md_state{device="md00",state="inactive"} 0
         node_md_state{device="md00",state="recovering"} 0
+        node_md_state{device="md00",state="reshape"} 0
         node_md_state{device="md00",state="resync"} 0
         node_md_state{device="md10",state="active"} 1
         node_md_state{device="md10",state="check"} 0
+        node_md_state{device="md10",state="degraded"} 0
         node_md_state{device="md10",state="inactive"} 0
         node_md_state{device="md10",state="recovering"} 0
+        node_md_state{device="md10",state="reshape"} 0
         node_md_state{device="md10",state="resync"} 0
         node_md_state{device="md101",state="active"} 1
         node_md_state{device="md101",state="check"} 0
+        node_md_state{device="md101",state="degraded"} 0
         node_md_state{device="md101",state="inactive"} 0
         node_md_state{device="md101",state="recovering"} 0
+        node_md_state{device="md101",state="reshape"} 0
         node_md_state{device="md101",state="resync"} 0
         node_md_state{device="md11",state="active"} 0
         node_md_state{device="md11",state="check"} 0
+        node_md_state{device="md11",state="degraded"} 0
         node_md_state{device="md11",state="inactive"} 0
         node_md_state{device="md11",state="recovering"} 0
+        node_md_state{device="md11",state="reshape"} 0
         node_md_state{device="md11",state="resync"} 1
         node_md_state{device="md12",state="active"} 1
         node_md_state{device="md12",state="check"} 0
+        node_md_state{device="md12",state="degraded"} 0
         node_md_state{device="md12",state="inactive"} 0
         node_md_state{device="md12",state="recovering"} 0
+        node_md_state{device="md12",state="reshape"} 0
         node_md_state{device="md12",state="resync"} 0
         node_md_state{device="md120",state="active"} 1
         node_md_state{device="md120",state="check"} 0
+        node_md_state{device="md120",state="degraded"} 0
         node_md_state{device="md120",state="inactive"} 0
         node_md_state{device="md120",state="recovering"} 0
+        node_md_state{device="md120",state="reshape"} 0
         node_md_state{device="md120",state="resync"} 0
         node_md_state{device="md126",state="active"} 1
         node_md_state{device="md126",state="check"} 0
+        node_md_state{device="md126",state="degraded"} 0
         node_md_state{device="md126",state="inactive"} 0
         node_md_state{device="md126",state="recovering"} 0
+        node_md_state{device="md126",state="reshape"} 0
         node_md_state{device="md126",state="resync"} 0
         node_md_state{device="md127",state="active"} 1
         node_md_state{device="md127",state="check"} 0
+        node_md_state{device="md127",state="degraded"} 0
         node_md_state{device="md127",state="inactive"} 0
         node_md_state{device="md127",state="recovering"} 0
+        node_md_state{device="md127",state="reshape"} 0
         node_md_state{device="md127",state="resync"} 0
         node_md_state{device="md201",state="active"} 0
         node_md_state{device="md201",state="check"} 1
+        node_md_state{device="md201",state="degraded"} 0
         node_md_state{device="md201",state="inactive"} 0
         node_md_state{device="md201",state="recovering"} 0
+        node_md_state{device="md201",state="reshape"} 0
         node_md_state{device="md201",state="resync"} 0
         node_md_state{device="md219",state="active"} 0
         node_md_state{device="md219",state="check"} 0
+        node_md_state{device="md219",state="degraded"} 0
         node_md_state{device="md219",state="inactive"} 1
         node_md_state{device="md219",state="recovering"} 0
+        node_md_state{device="md219",state="reshape"} 0
         node_md_state{device="md219",state="resync"} 0
         node_md_state{device="md3",state="active"} 1
         node_md_state{device="md3",state="check"} 0
+        node_md_state{device="md3",state="degraded"} 0
         node_md_state{device="md3",state="inactive"} 0
         node_md_state{device="md3",state="recovering"} 0
+        node_md_state{device="md3",state="reshape"} 0
         node_md_state{device="md3",state="resync"} 0
+        node_md_state{device="md30",state="active"} 0
+        node_md_state{device="md30",state="check"} 0
+        node_md_state{device="md30",state="degraded"} 0
+        node_md_state{device="md30",state="inactive"} 0
+        node_md_state{device="md30",state="recovering"} 0
+        node_md_state{device="md30",state="reshape"} 1
+        node_md_state{device="md30",state="resync"} 0
         node_md_state{device="md4",state="active"} 0
         node_md_state{device="md4",state="check"} 0
+        node_md_state{device="md4",state="degraded"} 0
         node_md_state{device="md4",state="inactive"} 1
         node_md_state{device="md4",state="recovering"} 0
+        node_md_state{device="md4",state="reshape"} 0
         node_md_state{device="md4",state="resync"} 0
         node_md_state{device="md6",state="active"} 0
         node_md_state{device="md6",state="check"} 0
+        node_md_state{device="md6",state="degraded"} 1
         node_md_state{device="md6",state="inactive"} 0
         node_md_state{device="md6",state="recovering"} 1
+        node_md_state{device="md6",state="reshape"} 0
         node_md_state{device="md6",state="resync"} 0
         node_md_state{device="md7",state="active"} 1
         node_md_state{device="md7",state="check"} 0
+        node_md_state{device="md7",state="degraded"} 1
         node_md_state{device="md7",state="inactive"} 0
         node_md_state{device="md7",state="recovering"} 0
+        node_md_state{device="md7",state="reshape"} 0
         node_md_state{device="md7",state="resync"} 0
         node_md_state{device="md8",state="active"} 0
         node_md_state{device="md8",state="check"} 0
+        node_md_state{device="md8",state="degraded"} 0
         node_md_state{device="md8",state="inactive"} 0
         node_md_state{device="md8",state="recovering"} 0
+        node_md_state{device="md8",state="reshape"} 0
         node_md_state{device="md8",state="resync"} 1
         node_md_state{device="md9",state="active"} 0
         node_md_state{device="md9",state="check"} 0
+        node_md_state{device="md9",state="degraded"} 0
         node_md_state{device="md9",state="inactive"} 0
         node_md_state{device="md9",state="recovering"} 0
+        node_md_state{device="md9",state="reshape"} 0
         node_md_state{device="md9",state="resync"} 1
+        # HELP node_md_sync_completed Fraction (0-1) of the current sync operation that has completed.
+        # TYPE node_md_sync_completed gauge
+        node_md_sync_completed{device="md0"} 1
+        node_md_sync_completed{device="md00"} 1
+        node_md_sync_completed{device="md10"} 1
+        node_md_sync_completed{device="md101"} 1
+        node_md_sync_completed{device="md11"} 0
+        node_md_sync_completed{device="md12"} 1
+        node_md_sync_completed{device="md120"} 1
+        node_md_sync_completed{device="md126"} 1
+        node_md_sync_completed{device="md127"} 1
+        node_md_sync_completed{device="md201"} 0.05726759116589625
+        node_md_sync_completed{device="md219"} 1
+        node_md_sync_completed{device="md3"} 1
+        node_md_sync_completed{device="md30"} 0.12504062696582094
+        node_md_sync_completed{device="md4"} 1
+        node_md_sync_completed{device="md6"} 0.08589186232948556
+        node_md_sync_completed{device="md7"} 1
+        node_md_sync_completed{device="md8"} 0.08589186232948556
+        node_md_sync_completed{device="md9"} 0
+        # HELP node_md_sync_total Number of blocks to be synced by the current sync operation.
+        # TYPE node_md_sync_total gauge
+        node_md_sync_total{device="md0"} 248896
+        node_md_sync_total{device="md00"} 4.186624e+06
+        node_md_sync_total{device="md10"} 3.14159265e+08
+        node_md_sync_total{device="md101"} 322560
+        node_md_sync_total{device="md11"} 4.190208e+06
+        node_md_sync_total{device="md12"} 3.886394368e+09
+        node_md_sync_total{device="md120"} 2.095104e+06
+        node_md_sync_total{device="md126"} 1.855870976e+09
+        node_md_sync_total{device="md127"} 3.12319552e+08
+        node_md_sync_total{device="md201"} 1.993728e+06
+        node_md_sync_total{device="md219"} 7932
+        node_md_sync_total{device="md3"} 5.853468288e+09
+        node_md_sync_total{device="md30"} 1.9533824e+09
+        node_md_sync_total{device="md4"} 4.883648e+06
+        node_md_sync_total{device="md6"} 1.95310144e+08
+        node_md_sync_total{device="md7"} 7.813735424e+09
+        node_md_sync_total{device="md8"} 1.95310144e+08
+        node_md_sync_total{device="md9"} 523968
 `
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level:     slog.LevelError,
@@ -18,6 +18,7 @@ package collector
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -65,6 +66,11 @@ func (c *meminfoNumaCollector) Update(ch chan<- prometheus.Metric) error {
 	if err != nil {
 		return fmt.Errorf("couldn't get NUMA meminfo: %w", err)
 	}
+	// Kernels without NUMA support don't have any nodes in sysfs.
+	if len(metrics) == 0 {
+		c.logger.Debug("no NUMA nodes found")
+		return ErrNoData
+	}
 	for _, v := range metrics {
 		desc, ok := c.metricDescs[v.metricName]
 		if !ok {
@@ -84,42 +90,54 @@ func getMemInfoNuma() ([]meminfoMetric, error) {
 		metrics []meminfoMetric
 	)
 
+	// Nodes are globbed on every scrape to pick up hotplugged memory.
 	nodes, err := filepath.Glob(sysFilePath("devices/system/node/node[0-9]*"))
 	if err != nil {
 		return nil, err
 	}
 	for _, node := range nodes {
-		meminfoFile, err := os.Open(filepath.Join(node, "meminfo"))
+		nodeMetrics, err := getMemInfoNumaNode(node)
 		if err != nil {
+			// The node was offlined since it was globbed.
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
 			return nil, err
 		}
-		defer meminfoFile.Close()
+		metrics = append(metrics, nodeMetrics...)
+	}
 
-		numaInfo, err := parseMemInfoNuma(meminfoFile)
-		if err != nil {
-			return nil, err
-		}
-		metrics = append(metrics, numaInfo...)
+	return metrics, nil
+}
 
-		numastatFile, err := os.Open(filepath.Join(node, "numastat"))
-		if err != nil {
-			return nil, err
-		}
-		defer numastatFile.Close()
+func getMemInfoNumaNode(node string) ([]meminfoMetric, error) {
+	nodeNumber := meminfoNodeRE.FindStringSubmatch(node)
+	if nodeNumber == nil {
+		return nil, fmt.Errorf("device node string didn't match regexp: %s", node)
+	}
 
-		nodeNumber := meminfoNodeRE.FindStringSubmatch(node)
-		if nodeNumber == nil {
-			return nil, fmt.Errorf("device node string didn't match regexp: %s", node)
-		}
+	meminfoFile, err := os.Open(filepath.Join(node, "meminfo"))
+	if err != nil {
+		return nil, err
+	}
+	defer meminfoFile.Close()
 
-		numaStat, err := parseMemInfoNumaStat(numastatFile, nodeNumber[1])
-		if err != nil {
-			return nil, err
-		}
-		metrics = append(metrics, numaStat...)
+	metrics, err := parseMemInfoNuma(meminfoFile)
+	if err != nil {
+		return nil, err
 	}
 
-	return metrics, nil
+	numastatFile, err := os.Open(filepath.Join(node, "numastat"))
+	if err != nil {
+		return nil, err
+	}
+	defer numastatFile.Close()
+
+	numaStat, err := parseMemInfoNumaStat(numastatFile, nodeNumber[1])
+	if err != nil {
+		return nil, err
+	}
+	return append(metrics, numaStat...), nil
 }
 
 func parseMemInfoNuma(r io.Reader) ([]meminfoMetric, error) {
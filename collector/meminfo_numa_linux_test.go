@@ -17,10 +17,81 @@
 package collector
 
 import (
+	"errors"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
+type testMeminfoNumaCollector struct {
+	mc Collector
+}
+
+func (c testMeminfoNumaCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mc.Update(ch)
+}
+
+func (c testMeminfoNumaCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestMeminfoNumaCollector(t *testing.T) {
+	sys := t.TempDir()
+	node0 := filepath.Join(sys, "devices/system/node/node0")
+	// node1 was offlined, its directory is left without files.
+	if err := os.MkdirAll(filepath.Join(sys, "devices/system/node/node1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(node0, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(node0, "meminfo"), []byte("Node 0 MemFree:         1024 kB\nNode 0 HugePages_Total:     0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(node0, "numastat"), []byte("numa_hit 100\nnuma_miss 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", sys}); err != nil {
+		t.Fatal(err)
+	}
+	mc, err := NewMeminfoNumaCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_memory_numa_HugePages_Total Memory information field HugePages_Total.
+		# TYPE node_memory_numa_HugePages_Total gauge
+		node_memory_numa_HugePages_Total{node="0"} 0
+		# HELP node_memory_numa_MemFree Memory information field MemFree.
+		# TYPE node_memory_numa_MemFree gauge
+		node_memory_numa_MemFree{node="0"} 1.048576e+06
+		# HELP node_memory_numa_numa_hit_total Memory information field numa_hit_total.
+		# TYPE node_memory_numa_numa_hit_total counter
+		node_memory_numa_numa_hit_total{node="0"} 100
+		# HELP node_memory_numa_numa_miss_total Memory information field numa_miss_total.
+		# TYPE node_memory_numa_numa_miss_total counter
+		node_memory_numa_numa_miss_total{node="0"} 3
+`
+	if err := testutil.CollectAndCompare(testMeminfoNumaCollector{mc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without NUMA support there are no nodes at all.
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.Update(make(chan prometheus.Metric, 1)); !errors.Is(err, ErrNoData) {
+		t.Fatalf("want ErrNoData, got %v", err)
+	}
+}
+
 func TestMemInfoNuma(t *testing.T) {
 	file, err := os.Open("fixtures/sys/devices/system/node/node0/meminfo")
 	if err != nil {
@@ -0,0 +1,123 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nonetclass
+// +build !nonetclass
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testNetClassCollector struct {
+	nc Collector
+}
+
+func (c testNetClassCollector) Collect(ch chan<- prometheus.Metric) {
+	c.nc.Update(ch)
+}
+
+func (c testNetClassCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestNetClassCollector(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			// bond0 reports a speed of -1, which is exported by default.
+			name: "default",
+			args: []string{},
+			want: `# HELP node_network_speed_bytes Network device property: speed_bytes
+				# TYPE node_network_speed_bytes gauge
+				node_network_speed_bytes{device="bond0"} -125000
+				node_network_speed_bytes{device="dmz"} 1.25e+08
+				node_network_speed_bytes{device="eth0"} 1.25e+08
+				node_network_speed_bytes{device="int"} 1.25e+08
+				# HELP node_network_up Value is 1 if operstate is 'up', 0 otherwise.
+				# TYPE node_network_up gauge
+				node_network_up{device="bond0"} 1
+				node_network_up{device="dmz"} 1
+				node_network_up{device="eth0"} 1
+				node_network_up{device="int"} 1
+`,
+		},
+		{
+			name: "ignored devices",
+			args: []string{"--collector.netclass.ignored-devices", "^(dmz|int)$", "--collector.netclass.ignore-invalid-speed"},
+			want: `# HELP node_network_speed_bytes Network device property: speed_bytes
+				# TYPE node_network_speed_bytes gauge
+				node_network_speed_bytes{device="eth0"} 1.25e+08
+				# HELP node_network_up Value is 1 if operstate is 'up', 0 otherwise.
+				# TYPE node_network_up gauge
+				node_network_up{device="bond0"} 1
+				node_network_up{device="eth0"} 1
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := kingpin.CommandLine.Parse(append([]string{"--path.sysfs", "fixtures/sys"}, test.args...)); err != nil {
+				t.Fatal(err)
+			}
+			nc, err := NewNetClassCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutil.CollectAndCompare(testNetClassCollector{nc}, strings.NewReader(test.want), "node_network_speed_bytes", "node_network_up"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNetClassCollectorDefaultIgnoredDevices(t *testing.T) {
+	sysfs := t.TempDir()
+	for _, device := range []string{"docker0", "eth0", "lo", "veth1a2b3c"} {
+		dir := filepath.Join(sysfs, "class/net", device)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "operstate"), []byte("up\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", sysfs}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *sysPath = "fixtures/sys" }()
+
+	nc, err := NewNetClassCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP node_network_up Value is 1 if operstate is 'up', 0 otherwise.
+		# TYPE node_network_up gauge
+		node_network_up{device="eth0"} 1
+`
+	if err := testutil.CollectAndCompare(testNetClassCollector{nc}, strings.NewReader(want), "node_network_up"); err != nil {
+		t.Fatal(err)
+	}
+}
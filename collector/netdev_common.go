@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"regexp"
+	"sort"
 	"strconv"
 	"sync"
 
@@ -77,11 +79,17 @@ func NewNetDevCollector(logger *slog.Logger) (Collector, error) {
 	}
 
 	if *netdevDeviceExclude != "" {
+		if _, err := regexp.Compile(*netdevDeviceExclude); err != nil {
+			return nil, fmt.Errorf("invalid --collector.netdev.device-exclude: %w", err)
+		}
 		logger.Info("Parsed flag --collector.netdev.device-exclude", "flag", *netdevDeviceExclude)
 	}
 
 	if *netdevDeviceInclude != "" {
-		logger.Info("Parsed Flag --collector.netdev.device-include", "flag", *netdevDeviceInclude)
+		if _, err := regexp.Compile(*netdevDeviceInclude); err != nil {
+			return nil, fmt.Errorf("invalid --collector.netdev.device-include: %w", err)
+		}
+		logger.Info("Parsed flag --collector.netdev.device-include", "flag", *netdevDeviceInclude)
 	}
 
 	return &netDevCollector{
@@ -108,6 +116,25 @@ func (c *netDevCollector) metricDesc(key string, labels []string) *prometheus.De
 	return c.metricDescs[key]
 }
 
+// directionalMetricDesc returns the descriptor of a statistic that is exported
+// with a direction label.
+func (c *netDevCollector) directionalMetricDesc(name, help string, labels []string) *prometheus.Desc {
+	c.metricDescsMutex.Lock()
+	defer c.metricDescsMutex.Unlock()
+
+	key := "direction:" + name
+	if _, ok := c.metricDescs[key]; !ok {
+		c.metricDescs[key] = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, c.subsystem, name+"_total"),
+			help,
+			append(labels[:len(labels):len(labels)], "direction"),
+			nil,
+		)
+	}
+
+	return c.metricDescs[key]
+}
+
 func (c *netDevCollector) Update(ch chan<- prometheus.Metric) error {
 	netDev, err := getNetDevStats(&c.deviceFilter, c.logger)
 	if err != nil {
@@ -119,24 +146,31 @@ func (c *netDevCollector) Update(ch chan<- prometheus.Metric) error {
 		return fmt.Errorf("couldn't get netdev labels: %w", err)
 	}
 
+	// Devices created after the labels were read get empty label values, so
+	// that all series of a metric have the same labels.
+	labelNames := netDevLabelNames(netDevLabels)
 	for dev, devStats := range netDev {
 		if !*netdevDetailedMetrics {
 			legacy(devStats)
 		}
 
-		labels := []string{"device"}
+		labels := append([]string{"device"}, labelNames...)
 		labelValues := []string{dev}
-		if devLabels, exists := netDevLabels[dev]; exists {
-			for labelName, labelValue := range devLabels {
-				labels = append(labels, labelName)
-				labelValues = append(labelValues, labelValue)
-			}
+		for _, labelName := range labelNames {
+			labelValues = append(labelValues, netDevLabels[dev][labelName])
 		}
 
 		for key, value := range devStats {
 			desc := c.metricDesc(key, labels)
 			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value), labelValues...)
 		}
+
+		for _, m := range directionalMetrics {
+			desc := c.directionalMetricDesc(m.name, m.help, labels)
+			for direction, value := range directionalStats(devStats, m.keys) {
+				ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value), append(labelValues[:len(labelValues):len(labelValues)], direction)...)
+			}
+		}
 	}
 	if *netdevAddressInfo {
 		interfaces, err := net.Interfaces()
@@ -148,7 +182,7 @@ func (c *netDevCollector) Update(ch chan<- prometheus.Metric) error {
 			"info"), "node network address by device",
 			[]string{"device", "address", "netmask", "scope"}, nil)
 
-		for _, addr := range getAddrsInfo(interfaces) {
+		for _, addr := range getAddrsInfo(interfaces, &c.deviceFilter) {
 			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1,
 				addr.device, addr.addr, addr.netmask, addr.scope)
 		}
@@ -156,6 +190,65 @@ func (c *netDevCollector) Update(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
+// netDevLabelNames returns the sorted names of the labels of all devices.
+func netDevLabelNames(netDevLabels map[string]map[string]string) []string {
+	seen := map[string]struct{}{}
+	for _, devLabels := range netDevLabels {
+		for labelName := range devLabels {
+			seen[labelName] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for labelName := range seen {
+		names = append(names, labelName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// directionalMetrics are exported with a direction label in addition to the
+// per-direction metrics they are derived from, so that both directions can be
+// selected with a single metric name.
+var directionalMetrics = []struct {
+	name string
+	help string
+	// keys are the statistics holding the value of each direction, with
+	// their legacy name first.
+	keys map[string][]string
+}{
+	{
+		name: "errors",
+		help: "Network device errors by direction.",
+		keys: map[string][]string{
+			"rx": {"receive_errs", "receive_errors"},
+			"tx": {"transmit_errs", "transmit_errors"},
+		},
+	},
+	{
+		name: "drops",
+		help: "Network device dropped packets by direction.",
+		keys: map[string][]string{
+			"rx": {"receive_drop", "receive_dropped"},
+			"tx": {"transmit_drop", "transmit_dropped"},
+		},
+	},
+}
+
+// directionalStats returns the value of each direction of a statistic from the
+// stats of a device, skipping directions the device doesn't report.
+func directionalStats(devStats map[string]uint64, keys map[string][]string) map[string]uint64 {
+	stats := map[string]uint64{}
+	for direction, names := range keys {
+		for _, name := range names {
+			if value, ok := devStats[name]; ok {
+				stats[direction] = value
+				break
+			}
+		}
+	}
+	return stats
+}
+
 type addrInfo struct {
 	device  string
 	addr    string
@@ -179,12 +272,19 @@ func scope(ip net.IP) string {
 	return ""
 }
 
-// getAddrsInfo returns interface name, address, scope and netmask for all interfaces.
-func getAddrsInfo(interfaces []net.Interface) []addrInfo {
+// interfaceAddrs is replaced in tests.
+var interfaceAddrs = func(ifs net.Interface) ([]net.Addr, error) { return ifs.Addrs() }
+
+// getAddrsInfo returns interface name, address, scope and netmask for all
+// interfaces not ignored by filter.
+func getAddrsInfo(interfaces []net.Interface, filter *deviceFilter) []addrInfo {
 	var res []addrInfo
 
 	for _, ifs := range interfaces {
-		addrs, _ := ifs.Addrs()
+		if filter.ignored(ifs.Name) {
+			continue
+		}
+		addrs, _ := interfaceAddrs(ifs)
 		for _, addr := range addrs {
 			ip, ipNet, err := net.ParseCIDR(addr.String())
 			if err != nil {
@@ -17,25 +17,92 @@
 package collector
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/jsimonetti/rtnetlink/v2"
 	"github.com/prometheus/procfs"
-	"github.com/prometheus/procfs/sysfs"
 )
 
 var (
 	netDevNetlink      = kingpin.Flag("collector.netdev.netlink", "Use netlink to gather stats instead of /proc/net/dev.").Default("true").Bool()
 	netdevLabelIfAlias = kingpin.Flag("collector.netdev.label-ifalias", "Add ifAlias label").Default("false").Bool()
+	netdevIPv6Stats    = kingpin.Flag("collector.netdev.ipv6-stats", "Add the IPv6 traffic of each device from /proc/net/dev_snmp6.").Default("false").Bool()
 )
 
 func getNetDevStats(filter *deviceFilter, logger *slog.Logger) (netDevStats, error) {
+	var (
+		stats netDevStats
+		err   error
+	)
 	if *netDevNetlink {
-		return netlinkStats(filter, logger)
+		stats, err = netlinkStats(filter, logger)
+	} else {
+		stats, err = procNetDevStats(filter, logger)
 	}
-	return procNetDevStats(filter, logger)
+	if err != nil || !*netdevIPv6Stats {
+		return stats, err
+	}
+	return stats, addIPv6Stats(stats, logger)
+}
+
+// addIPv6Stats adds the IPv6 byte counters of /proc/net/dev_snmp6/<device>
+// to the stats of each device. Devices without IPv6 don't have the file.
+func addIPv6Stats(stats netDevStats, logger *slog.Logger) error {
+	for dev, devStats := range stats {
+		snmp6, err := parseDevSNMP6(procFilePath(filepath.Join("net/dev_snmp6", dev)))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				logger.Debug("No IPv6 statistics for device", "device", dev)
+				continue
+			}
+			return fmt.Errorf("couldn't get IPv6 statistics of %s: %w", dev, err)
+		}
+		for key, counter := range map[string]string{
+			"ipv6_receive_bytes":  "Ip6InOctets",
+			"ipv6_transmit_bytes": "Ip6OutOctets",
+		} {
+			if value, ok := snmp6[counter]; ok {
+				devStats[key] = value
+			}
+		}
+	}
+	return nil
+}
+
+// parseDevSNMP6 parses a per-device snmp6 file, which has one "<counter>
+// <value>" pair per line.
+func parseDevSNMP6(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	counters := map[string]uint64{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid line %q", scanner.Text())
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in line %q: %w", scanner.Text(), err)
+		}
+		counters[fields[0]] = value
+	}
+	return counters, scanner.Err()
 }
 
 func netlinkStats(filter *deviceFilter, logger *slog.Logger) (netDevStats, error) {
@@ -191,19 +258,27 @@ func getNetDevLabels() (map[string]map[string]string, error) {
 		return nil, nil
 	}
 
-	fs, err := sysfs.NewFS(*sysPath)
-	if err != nil {
-		return nil, err
-	}
-
-	interfaces, err := fs.NetClass()
+	devices, err := os.ReadDir(sysFilePath("class/net"))
 	if err != nil {
 		return nil, err
 	}
 
 	labels := make(map[string]map[string]string)
-	for iface, params := range interfaces {
-		labels[iface] = map[string]string{"ifalias": params.IfAlias}
+	for _, device := range devices {
+		// Skip files like bonding_masters, devices are directories or
+		// symlinks to them.
+		if device.Type().IsRegular() {
+			continue
+		}
+		alias, err := os.ReadFile(sysFilePath(filepath.Join("class/net", device.Name(), "ifalias")))
+		if err != nil {
+			// The device was deleted since listing class/net.
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		labels[device.Name()] = map[string]string{"ifalias": strings.TrimSpace(string(alias))}
 	}
 
 	return labels, nil
@@ -19,9 +19,17 @@ package collector
 import (
 	"io"
 	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/alecthomas/kingpin/v2"
 	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 var links = []rtnetlink.LinkMessage{
@@ -339,3 +347,161 @@ func TestNetDevMetricValues(t *testing.T) {
 		}
 	}
 }
+
+func TestGetAddrsInfoDeviceFilter(t *testing.T) {
+	addrs := map[string][]net.Addr{
+		"lo":   {&net.IPNet{IP: net.IPv4(127, 0, 0, 1), Mask: net.CIDRMask(8, 32)}},
+		"eth0": {&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)}},
+	}
+	defer func(orig func(net.Interface) ([]net.Addr, error)) { interfaceAddrs = orig }(interfaceAddrs)
+	interfaceAddrs = func(ifs net.Interface) ([]net.Addr, error) { return addrs[ifs.Name], nil }
+	interfaces := []net.Interface{{Index: 1, Name: "lo"}, {Index: 2, Name: "eth0"}}
+
+	for _, test := range []struct {
+		exclude string
+		want    []addrInfo
+	}{
+		{
+			want: []addrInfo{
+				{device: "lo", addr: "127.0.0.1", scope: "link-local", netmask: "8"},
+				{device: "eth0", addr: "fe80::1", scope: "link-local", netmask: "64"},
+			},
+		},
+		{
+			exclude: "^lo$",
+			want: []addrInfo{
+				{device: "eth0", addr: "fe80::1", scope: "link-local", netmask: "64"},
+			},
+		},
+	} {
+		filter := newDeviceFilter(test.exclude, "")
+		if got := getAddrsInfo(interfaces, &filter); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("exclude %q: want %v, got %v", test.exclude, test.want, got)
+		}
+	}
+}
+
+func TestNetDevDirectionalStats(t *testing.T) {
+	for _, detailed := range []bool{false, true} {
+		filter := newDeviceFilter("", "^enp0s0f0$")
+		netStats := parseNetlinkStats(links, &filter, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		metrics := netStats["enp0s0f0"]
+		receive, transmit := "receive_errors", "transmit_errors"
+		receiveDrop, transmitDrop := "receive_dropped", "transmit_dropped"
+		if !detailed {
+			legacy(metrics)
+			receive, transmit = "receive_errs", "transmit_errs"
+			receiveDrop, transmitDrop = "receive_drop", "transmit_drop"
+		}
+
+		for _, m := range directionalMetrics {
+			got := directionalStats(metrics, m.keys)
+			want := map[string]uint64{"rx": metrics[receive], "tx": metrics[transmit]}
+			if m.name == "drops" {
+				want = map[string]uint64{"rx": metrics[receiveDrop], "tx": metrics[transmitDrop]}
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("detailed=%t: want %s %v, got %v", detailed, m.name, want, got)
+			}
+		}
+	}
+}
+
+func TestNetDevIPv6Stats(t *testing.T) {
+	procfs := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(procfs, "net/dev_snmp6"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	snmp6 := "ifIndex                         \t2\nIp6InReceives                   \t1842\nIp6InOctets                     \t262144\nIp6OutOctets                    \t131072\n"
+	if err := os.WriteFile(filepath.Join(procfs, "net/dev_snmp6/eth0"), []byte(snmp6), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", procfs}); err != nil {
+		t.Fatal(err)
+	}
+
+	// IPv6 is disabled on tun0, so it has no dev_snmp6 file.
+	stats := netDevStats{
+		"eth0": {"receive_bytes": 300000},
+		"tun0": {"receive_bytes": 1888},
+	}
+	if err := addIPv6Stats(stats, slog.New(slog.NewTextHandler(io.Discard, nil))); err != nil {
+		t.Fatal(err)
+	}
+
+	want := netDevStats{
+		"eth0": {"receive_bytes": 300000, "ipv6_receive_bytes": 262144, "ipv6_transmit_bytes": 131072},
+		"tun0": {"receive_bytes": 1888},
+	}
+	if !reflect.DeepEqual(want, stats) {
+		t.Errorf("want %v, got %v", want, stats)
+	}
+}
+
+type testNetDevCollector struct {
+	nc Collector
+}
+
+func (c testNetDevCollector) Collect(ch chan<- prometheus.Metric) {
+	c.nc.Update(ch)
+}
+
+func (c testNetDevCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestNetDevLabelIfAlias(t *testing.T) {
+	procfs := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(procfs, "net"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	dev := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+  eth0:  300000     250    0    0    0     0          0         0   150000     120    0    0    0     0       0          0
+ veth1:    1888      24    0    0    0     0          0         0    67120     934    0    0    0     0       0          0
+`
+	if err := os.WriteFile(filepath.Join(procfs, "net/dev"), []byte(dev), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// veth1 was created after class/net was read, and gone was deleted
+	// after it was listed.
+	sysfs := t.TempDir()
+	for _, dir := range []string{"class/net/eth0", "class/net/gone"} {
+		if err := os.MkdirAll(filepath.Join(sysfs, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(sysfs, "class/net/eth0/ifalias"), []byte("uplink\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sysfs, "class/net/bonding_masters"), []byte("bond0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", procfs, "--path.sysfs", sysfs, "--no-collector.netdev.netlink", "--collector.netdev.label-ifalias"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *netDevNetlink, *netdevLabelIfAlias = true, false }()
+
+	nc, err := NewNetDevCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP node_network_receive_bytes_total Network device statistic receive_bytes.
+		# TYPE node_network_receive_bytes_total counter
+		node_network_receive_bytes_total{device="eth0",ifalias="uplink"} 300000
+		node_network_receive_bytes_total{device="veth1",ifalias=""} 1888
+`
+	if err := testutil.CollectAndCompare(testNetDevCollector{nc}, strings.NewReader(want), "node_network_receive_bytes_total"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNetDevInvalidDeviceFilter(t *testing.T) {
+	*netdevDeviceInclude = "(veth"
+	defer func() { *netdevDeviceInclude = "" }()
+
+	if _, err := NewNetDevCollector(slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("expected an error for an invalid device-include")
+	}
+}
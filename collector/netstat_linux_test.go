@@ -17,8 +17,16 @@
 package collector
 
 import (
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestNetStats(t *testing.T) {
@@ -97,3 +105,108 @@ func testSNMP6Stats(t *testing.T, fileName string) {
 		t.Errorf("want netstat Udp6 SndbufErrors %s, got %s", want, got)
 	}
 }
+
+type testNetStatCollector struct {
+	nc Collector
+}
+
+func (c testNetStatCollector) Collect(ch chan<- prometheus.Metric) {
+	c.nc.Update(ch)
+}
+
+func (c testNetStatCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestNetStatCollector(t *testing.T) {
+	// Without snmp6, as on hosts with IPv6 disabled.
+	noIPv6 := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(noIPv6, "net"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"netstat", "snmp"} {
+		b, err := os.ReadFile(filepath.Join("fixtures/proc/net", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(noIPv6, "net", name), b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	retransmits := `# HELP node_netstat_TcpExt_TCPLostRetransmit Statistic TcpExtTCPLostRetransmit.
+		# TYPE node_netstat_TcpExt_TCPLostRetransmit untyped
+		node_netstat_TcpExt_TCPLostRetransmit 0
+		# HELP node_netstat_Tcp_InSegs Statistic TcpInSegs.
+		# TYPE node_netstat_Tcp_InSegs untyped
+		node_netstat_Tcp_InSegs 5.7252008e+07
+		# HELP node_netstat_Tcp_OutSegs Statistic TcpOutSegs.
+		# TYPE node_netstat_Tcp_OutSegs untyped
+		node_netstat_Tcp_OutSegs 5.4915039e+07
+		# HELP node_netstat_Tcp_RetransSegs Statistic TcpRetransSegs.
+		# TYPE node_netstat_Tcp_RetransSegs untyped
+		node_netstat_Tcp_RetransSegs 227
+`
+	for _, test := range []struct {
+		name  string
+		proc  string
+		extra string
+	}{
+		{
+			name: "snmp6",
+			proc: "fixtures/proc",
+			extra: `# HELP node_netstat_Ip6_InOctets Statistic Ip6InOctets.
+				# TYPE node_netstat_Ip6_InOctets untyped
+				node_netstat_Ip6_InOctets 460
+`,
+		},
+		{
+			name: "no snmp6",
+			proc: noIPv6,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", test.proc}); err != nil {
+				t.Fatal(err)
+			}
+			nc, err := NewNetStatCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := testutil.CollectAndCompare(testNetStatCollector{nc}, strings.NewReader(retransmits+test.extra),
+				"node_netstat_TcpExt_TCPLostRetransmit", "node_netstat_Tcp_InSegs", "node_netstat_Tcp_OutSegs",
+				"node_netstat_Tcp_RetransSegs", "node_netstat_Ip6_InOctets"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNetStatCollectorFields(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc", "--collector.netstat.fields", "^(TcpExt_ListenDrops|Udp6_InDatagrams)$"}); err != nil {
+		t.Fatal(err)
+	}
+	nc, err := NewNetStatCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_netstat_TcpExt_ListenDrops Statistic TcpExtListenDrops.
+		# TYPE node_netstat_TcpExt_ListenDrops untyped
+		node_netstat_TcpExt_ListenDrops 0
+		# HELP node_netstat_Udp6_InDatagrams Statistic Udp6InDatagrams.
+		# TYPE node_netstat_Udp6_InDatagrams untyped
+		node_netstat_Udp6_InDatagrams 0
+`
+	if err := testutil.CollectAndCompare(testNetStatCollector{nc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := kingpin.CommandLine.Parse([]string{"--collector.netstat.fields", "Tcp_(InSegs"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewNetStatCollector(slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("want error for invalid fields regexp")
+	}
+}
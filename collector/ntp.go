@@ -39,6 +39,7 @@ var (
 	ntpProtocolVersion = kingpin.Flag("collector.ntp.protocol-version", "NTP protocol version").Default("4").Int()
 	ntpServerIsLocal   = kingpin.Flag("collector.ntp.server-is-local", "Certify that collector.ntp.server address is not a public ntp server").Default("false").Bool()
 	ntpIPTTL           = kingpin.Flag("collector.ntp.ip-ttl", "IP TTL to use while sending NTP query").Default("1").Int()
+	ntpTimeout         = kingpin.Flag("collector.ntp.timeout", "Timeout of the NTP query, the default matches ntpdate").Default("1s").Duration()
 	// 3.46608s ~ 1.5s + PHI * (1 << maxPoll), where 1.5s is MAXDIST from ntp.org, it is 1.0 in RFC5905
 	// max-distance option is used as-is without phi*(1<<poll)
 	ntpMaxDistance     = kingpin.Flag("collector.ntp.max-distance", "Max accumulated distance to the root").Default("3.46608s").Duration()
@@ -79,6 +80,10 @@ func NewNtpCollector(logger *slog.Logger) (Collector, error) {
 		return nil, fmt.Errorf("invalid NTP port number %d; must be between 1 and 65535 inclusive", *ntpServerPort)
 	}
 
+	if *ntpTimeout <= 0 {
+		return nil, fmt.Errorf("invalid NTP query timeout %s; must be positive", *ntpTimeout)
+	}
+
 	logger.Warn("This collector is deprecated and will be removed in the next major version release.")
 	return &ntpCollector{
 		stratum: typedDesc{prometheus.NewDesc(
@@ -129,7 +134,7 @@ func (c *ntpCollector) Update(ch chan<- prometheus.Metric) error {
 	resp, err := ntp.QueryWithOptions(*ntpServer, ntp.QueryOptions{
 		Version: *ntpProtocolVersion,
 		TTL:     *ntpIPTTL,
-		Timeout: time.Second, // default `ntpdate` timeout
+		Timeout: *ntpTimeout,
 		Port:    *ntpServerPort,
 	})
 	if err != nil {
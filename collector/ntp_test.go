@@ -0,0 +1,68 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nontp
+// +build !nontp
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestNtpTimeout(t *testing.T) {
+	for _, timeout := range []string{"0s", "-1s"} {
+		if _, err := kingpin.CommandLine.Parse([]string{"--collector.ntp.timeout=" + timeout}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewNtpCollector(slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+			t.Fatalf("want error for timeout %s", timeout)
+		}
+	}
+
+	// A server that never replies.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--collector.ntp.server-port", strconv.Itoa(port),
+		"--collector.ntp.timeout", "50ms",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewNtpCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := make(chan prometheus.Metric, 10)
+	start := time.Now()
+	if err := c.Update(ch); err == nil {
+		t.Fatal("want error from a server that doesn't reply")
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Fatalf("query took %s, want it to time out after 50ms", elapsed)
+	}
+}
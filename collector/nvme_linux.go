@@ -21,13 +21,14 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs/sysfs"
 )
 
 type nvmeCollector struct {
-	fs     sysfs.FS
 	logger *slog.Logger
 }
 
@@ -37,19 +38,16 @@ func init() {
 
 // NewNVMeCollector returns a new Collector exposing NVMe stats.
 func NewNVMeCollector(logger *slog.Logger) (Collector, error) {
-	fs, err := sysfs.NewFS(*sysPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open sysfs: %w", err)
-	}
-
 	return &nvmeCollector{
-		fs:     fs,
 		logger: logger,
 	}, nil
 }
 
 func (c *nvmeCollector) Update(ch chan<- prometheus.Metric) error {
-	devices, err := c.fs.NVMeClass()
+	// The devices are read one by one instead of with NVMeClass, which fails
+	// as a whole when a single device vanishes while it is read, e.g. on
+	// hot-unplug.
+	dirs, err := os.ReadDir(sysFilePath("class/nvme"))
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			c.logger.Debug("nvme statistics not found, skipping")
@@ -58,7 +56,15 @@ func (c *nvmeCollector) Update(ch chan<- prometheus.Metric) error {
 		return fmt.Errorf("error obtaining NVMe class info: %w", err)
 	}
 
-	for _, device := range devices {
+	for _, dir := range dirs {
+		device, err := readNVMeDevice(dir.Name())
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				c.logger.Debug("NVMe device disappeared during collection, skipping", "device", dir.Name(), "err", err)
+				continue
+			}
+			return fmt.Errorf("error obtaining NVMe class info: %w", err)
+		}
 		infoDesc := prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "nvme", "info"),
 			"Non-numeric data from /sys/class/nvme/<device>, value is always 1.",
@@ -71,3 +77,22 @@ func (c *nvmeCollector) Update(ch chan<- prometheus.Metric) error {
 
 	return nil
 }
+
+// readNVMeDevice reads the attributes of the NVMe device name exposed in the
+// info metric.
+func readNVMeDevice(name string) (sysfs.NVMeDevice, error) {
+	device := sysfs.NVMeDevice{Name: name}
+	for file, value := range map[string]*string{
+		"firmware_rev": &device.FirmwareRevision,
+		"model":        &device.Model,
+		"serial":       &device.Serial,
+		"state":        &device.State,
+	} {
+		data, err := os.ReadFile(sysFilePath(filepath.Join("class/nvme", name, file)))
+		if err != nil {
+			return sysfs.NVMeDevice{}, err
+		}
+		*value = strings.TrimSpace(string(data))
+	}
+	return device, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nonvme
+// +build !nonvme
+
+package collector
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testNVMeCollector struct {
+	nc Collector
+}
+
+func (c testNVMeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.nc.Update(ch)
+}
+
+func (c testNVMeCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestNVMe(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	nc, err := NewNVMeCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_nvme_info Non-numeric data from /sys/class/nvme/<device>, value is always 1.
+		# TYPE node_nvme_info gauge
+		node_nvme_info{device="nvme0",firmware_revision="1B2QEXP7",model="Samsung SSD 970 PRO 512GB",serial="S680HF8N190894I",state="live"} 1
+`
+	if err := testutil.CollectAndCompare(testNVMeCollector{nc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNVMeNoDevices(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	nc, err := NewNVMeCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nc.Update(make(chan prometheus.Metric, 1)); !errors.Is(err, ErrNoData) {
+		t.Fatalf("want ErrNoData, got %v", err)
+	}
+}
+
+func TestNVMeDeviceDisappeared(t *testing.T) {
+	sys := t.TempDir()
+	class := filepath.Join(sys, "class/nvme")
+	if err := os.MkdirAll(filepath.Join(class, "nvme0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for file, content := range map[string]string{
+		"firmware_rev": "1B2QEXP7\n",
+		"model":        "Samsung SSD 970 PRO 512GB\n",
+		"serial":       "S680HF8N190894I\n",
+		"state":        "live\n",
+	} {
+		if err := os.WriteFile(filepath.Join(class, "nvme0", file), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The class links to the device, whose directory is gone once it was
+	// unplugged.
+	if err := os.Symlink(filepath.Join(sys, "devices/nvme1"), filepath.Join(class, "nvme1")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", sys}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *sysPath = "fixtures/sys" }()
+	nc, err := NewNVMeCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_nvme_info Non-numeric data from /sys/class/nvme/<device>, value is always 1.
+		# TYPE node_nvme_info gauge
+		node_nvme_info{device="nvme0",firmware_revision="1B2QEXP7",model="Samsung SSD 970 PRO 512GB",serial="S680HF8N190894I",state="live"} 1
+`
+	if err := testutil.CollectAndCompare(testNVMeCollector{nc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+	if err := nc.Update(make(chan prometheus.Metric, 1)); err != nil {
+		t.Fatalf("want no error for the unplugged device, got %v", err)
+	}
+}
@@ -188,6 +188,9 @@ func (c *osReleaseCollector) Update(ch chan<- prometheus.Metric) error {
 		return err
 	}
 
+	// A concurrent scrape may be updating the struct.
+	c.osMutex.RLock()
+	defer c.osMutex.RUnlock()
 	ch <- prometheus.MustNewConstMetric(c.infoDesc, prometheus.GaugeValue, 1.0,
 		c.os.BuildID, c.os.ID, c.os.IDLike, c.os.ImageID, c.os.ImageVersion, c.os.Name, c.os.PrettyName,
 		c.os.Variant, c.os.VariantID, c.os.Version, c.os.VersionCodename, c.os.VersionID)
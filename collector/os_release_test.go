@@ -19,7 +19,10 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const debianBullseye string = `PRETTY_NAME="Debian GNU/Linux 11 (bullseye)"
@@ -147,3 +150,29 @@ func TestUpdateStruct(t *testing.T) {
 		t.Errorf("Expected '%v' but got '%v'", wantedVersion, c.version)
 	}
 }
+
+func TestOSReleaseConcurrentUpdate(t *testing.T) {
+	collector, err := NewOSCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := collector.(*osReleaseCollector)
+	c.osReleaseFilenames = []string{usrLibOSRelease}
+	defer func(rootfs string) { *rootfsPath = rootfs }(*rootfsPath)
+	*rootfsPath = "fixtures"
+
+	// Run with -race to catch metrics being read while another scrape
+	// updates them.
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ch := make(chan prometheus.Metric, 10)
+			if err := c.Update(ch); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -29,6 +29,21 @@ var (
 	udevDataPath = kingpin.Flag("path.udev.data", "udev data path.").Default("/run/udev/data").String()
 )
 
+// ProcPath returns the configured procfs mountpoint.
+func ProcPath() string {
+	return *procPath
+}
+
+// SysPath returns the configured sysfs mountpoint.
+func SysPath() string {
+	return *sysPath
+}
+
+// RootfsPath returns the configured rootfs mountpoint.
+func RootfsPath() string {
+	return *rootfsPath
+}
+
 func procFilePath(name string) string {
 	return filepath.Join(*procPath, name)
 }
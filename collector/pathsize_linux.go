@@ -0,0 +1,176 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nopathsize
+// +build !nopathsize
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/unix"
+)
+
+const pathSizeSubsystem = "path"
+
+var (
+	pathSizePaths   = kingpin.Flag("collector.pathsize.paths", "Path to report the size and free space of the filesystem holding it for. (repeatable)").Strings()
+	pathSizeTimeout = kingpin.Flag("collector.pathsize.timeout", "How long to wait for statfs() on a path, e.g. on a hung network filesystem.").Default("5s").Duration()
+
+	// pathStatfs is replaced in tests.
+	pathStatfs = unix.Statfs
+
+	errPathTimeout = errors.New("statfs timed out")
+)
+
+type pathSizeCollector struct {
+	paths     []string
+	timeout   time.Duration
+	size      typedDesc
+	free      typedDesc
+	avail     typedDesc
+	pathError typedDesc
+	statfsFn  func(string, *unix.Statfs_t) error
+	logger    *slog.Logger
+
+	// stuck holds the paths whose statfs() is still running after timing
+	// out, they aren't checked again until it returns.
+	stuckMtx sync.Mutex
+	stuck    map[string]struct{}
+}
+
+func init() {
+	registerCollector("pathsize", defaultDisabled, NewPathSizeCollector)
+}
+
+// NewPathSizeCollector returns a new Collector exposing the filesystem usage
+// of the configured paths.
+func NewPathSizeCollector(logger *slog.Logger) (Collector, error) {
+	if *pathSizeTimeout <= 0 {
+		return nil, fmt.Errorf("statfs timeout must be positive")
+	}
+	seen := map[string]struct{}{}
+	for _, path := range *pathSizePaths {
+		if _, ok := seen[path]; ok {
+			return nil, fmt.Errorf("duplicate path %q in --collector.pathsize.paths", path)
+		}
+		seen[path] = struct{}{}
+	}
+
+	labels := []string{"path"}
+	return &pathSizeCollector{
+		paths:   *pathSizePaths,
+		timeout: *pathSizeTimeout,
+		size: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, pathSizeSubsystem, "size_bytes"),
+			"Size of the filesystem holding the path in bytes.",
+			labels, nil,
+		), prometheus.GaugeValue},
+		free: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, pathSizeSubsystem, "free_bytes"),
+			"Free space of the filesystem holding the path in bytes.",
+			labels, nil,
+		), prometheus.GaugeValue},
+		avail: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, pathSizeSubsystem, "avail_bytes"),
+			"Space of the filesystem holding the path available to non-root users in bytes.",
+			labels, nil,
+		), prometheus.GaugeValue},
+		pathError: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, pathSizeSubsystem, "error"),
+			"Whether an error occurred while getting the size of the path.",
+			labels, nil,
+		), prometheus.GaugeValue},
+		statfsFn: pathStatfs,
+		logger:   logger,
+		stuck:    map[string]struct{}{},
+	}, nil
+}
+
+func (c *pathSizeCollector) Update(ch chan<- prometheus.Metric) error {
+	if len(c.paths) == 0 {
+		return ErrNoData
+	}
+
+	var wg sync.WaitGroup
+	for _, path := range c.paths {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			buf, err := c.statfs(path)
+			if err != nil {
+				c.logger.Debug("Error on statfs() system call", "path", path, "err", err)
+				ch <- c.pathError.mustNewConstMetric(1, path)
+				return
+			}
+			ch <- c.pathError.mustNewConstMetric(0, path)
+			ch <- c.size.mustNewConstMetric(float64(buf.Blocks)*float64(buf.Bsize), path)
+			ch <- c.free.mustNewConstMetric(float64(buf.Bfree)*float64(buf.Bsize), path)
+			ch <- c.avail.mustNewConstMetric(float64(buf.Bavail)*float64(buf.Bsize), path)
+		}(path)
+	}
+	wg.Wait()
+	return nil
+}
+
+// statfs runs statfs() on the path below the rootfs. Like for mount points,
+// it is left running in the background on timeout.
+func (c *pathSizeCollector) statfs(path string) (*unix.Statfs_t, error) {
+	c.stuckMtx.Lock()
+	if _, ok := c.stuck[path]; ok {
+		c.stuckMtx.Unlock()
+		return nil, errPathTimeout
+	}
+	c.stuckMtx.Unlock()
+
+	type statResult struct {
+		buf *unix.Statfs_t
+		err error
+	}
+	result := make(chan statResult, 1)
+	fullPath := rootfsFilePath(path)
+	go func() {
+		buf := new(unix.Statfs_t)
+		err := c.statfsFn(fullPath, buf)
+
+		c.stuckMtx.Lock()
+		defer c.stuckMtx.Unlock()
+		delete(c.stuck, path)
+		result <- statResult{buf, err}
+	}()
+
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-result:
+		return r.buf, r.err
+	case <-timer.C:
+		c.stuckMtx.Lock()
+		defer c.stuckMtx.Unlock()
+		select {
+		case r := <-result:
+			return r.buf, r.err
+		default:
+			c.stuck[path] = struct{}{}
+			return nil, errPathTimeout
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nopathsize
+// +build !nopathsize
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/sys/unix"
+)
+
+type testPathSizeCollector struct {
+	pc Collector
+}
+
+func (c testPathSizeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.pc.Update(ch)
+}
+
+func (c testPathSizeCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestPathSize(t *testing.T) {
+	hung := make(chan struct{})
+	defer close(hung)
+	pathStatfs = func(path string, buf *unix.Statfs_t) error {
+		switch path {
+		case "/host/var/lib/data":
+			buf.Bsize, buf.Blocks, buf.Bfree, buf.Bavail = 4096, 1000, 400, 300
+			return nil
+		case "/host/mnt/nfs":
+			<-hung
+		}
+		return unix.ENOENT
+	}
+	defer func() { pathStatfs = unix.Statfs }()
+
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--path.rootfs", "/host",
+		"--collector.pathsize.timeout", "10ms",
+		"--collector.pathsize.paths", "/var/lib/data",
+		"--collector.pathsize.paths", "/missing",
+		"--collector.pathsize.paths", "/mnt/nfs",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *rootfsPath, *pathSizePaths = "/", nil }()
+
+	pc, err := NewPathSizeCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP node_path_avail_bytes Space of the filesystem holding the path available to non-root users in bytes.
+		# TYPE node_path_avail_bytes gauge
+		node_path_avail_bytes{path="/var/lib/data"} 1.2288e+06
+		# HELP node_path_error Whether an error occurred while getting the size of the path.
+		# TYPE node_path_error gauge
+		node_path_error{path="/missing"} 1
+		node_path_error{path="/mnt/nfs"} 1
+		node_path_error{path="/var/lib/data"} 0
+		# HELP node_path_free_bytes Free space of the filesystem holding the path in bytes.
+		# TYPE node_path_free_bytes gauge
+		node_path_free_bytes{path="/var/lib/data"} 1.6384e+06
+		# HELP node_path_size_bytes Size of the filesystem holding the path in bytes.
+		# TYPE node_path_size_bytes gauge
+		node_path_size_bytes{path="/var/lib/data"} 4.096e+06
+`
+	// The hung path is skipped without waiting on the second scrape.
+	for i := 0; i < 2; i++ {
+		if err := testutil.CollectAndCompare(testPathSizeCollector{pc}, strings.NewReader(want)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := pc.(*pathSizeCollector).stuck["/mnt/nfs"]; !ok {
+		t.Error("expected /mnt/nfs to be marked as stuck")
+	}
+}
@@ -341,31 +341,40 @@ func NewPerfCollector(logger *slog.Logger) (Collector, error) {
 	if *perfHwProfilerFlag != nil && len(*perfHwProfilerFlag) > 0 {
 		// hardwareProfilers = 0
 		for _, hf := range *perfHwProfilerFlag {
-			if v, ok := perfHardwareProfilerMap[hf]; ok {
-				hardwareProfilers |= v
+			v, ok := perfHardwareProfilerMap[hf]
+			if !ok {
+				return nil, fmt.Errorf("unknown perf hardware profiler %q", hf)
 			}
+			hardwareProfilers |= v
 		}
 	}
 	softwareProfilers := perf.AllSoftwareProfilers
 	if *perfSwProfilerFlag != nil && len(*perfSwProfilerFlag) > 0 {
 		// softwareProfilers = 0
 		for _, sf := range *perfSwProfilerFlag {
-			if v, ok := perfSoftwareProfilerMap[sf]; ok {
-				softwareProfilers |= v
+			v, ok := perfSoftwareProfilerMap[sf]
+			if !ok {
+				return nil, fmt.Errorf("unknown perf software profiler %q", sf)
 			}
+			softwareProfilers |= v
 		}
 	}
 	cacheProfilers := perf.L1DataReadHitProfiler | perf.L1DataReadMissProfiler | perf.L1DataWriteHitProfiler | perf.L1InstrReadMissProfiler | perf.InstrTLBReadHitProfiler | perf.InstrTLBReadMissProfiler | perf.LLReadHitProfiler | perf.LLReadMissProfiler | perf.LLWriteHitProfiler | perf.LLWriteMissProfiler | perf.BPUReadHitProfiler | perf.BPUReadMissProfiler
 	if *perfCaProfilerFlag != nil && len(*perfCaProfilerFlag) > 0 {
 		cacheProfilers = 0
 		for _, cf := range *perfCaProfilerFlag {
-			if v, ok := perfCacheProfilerMap[cf]; ok {
-				cacheProfilers |= v
+			v, ok := perfCacheProfilerMap[cf]
+			if !ok {
+				return nil, fmt.Errorf("unknown perf cache profiler %q", cf)
 			}
+			cacheProfilers |= v
 		}
 	}
 
-	// Configure all profilers for the specified CPUs.
+	// Configure all profilers for the specified CPUs. CPUs that support none
+	// of the events, e.g. offline ones, are skipped, the collector only fails
+	// if no CPU supports them.
+	var hwErr, swErr, cacheErr error
 	for _, cpu := range cpus {
 		// Use -1 to profile all processes on the CPU, see:
 		// man perf_event_open
@@ -376,25 +385,29 @@ func NewPerfCollector(logger *slog.Logger) (Collector, error) {
 				hardwareProfilers,
 			)
 			if err != nil && !hwProf.HasProfilers() {
-				return nil, err
-			}
-			if err := hwProf.Start(); err != nil {
-				return nil, err
+				logger.Debug("No perf hardware profilers supported on CPU, skipping it", "cpu", cpu, "err", err)
+				hwErr = err
+			} else {
+				if err := hwProf.Start(); err != nil {
+					return nil, err
+				}
+				collector.perfHwProfilers[cpu] = &hwProf
+				collector.hwProfilerCPUMap[&hwProf] = cpu
 			}
-			collector.perfHwProfilers[cpu] = &hwProf
-			collector.hwProfilerCPUMap[&hwProf] = cpu
 		}
 
 		if !*perfNoSwProfiler {
 			swProf, err := perf.NewSoftwareProfiler(-1, cpu, softwareProfilers)
 			if err != nil && !swProf.HasProfilers() {
-				return nil, err
-			}
-			if err := swProf.Start(); err != nil {
-				return nil, err
+				logger.Debug("No perf software profilers supported on CPU, skipping it", "cpu", cpu, "err", err)
+				swErr = err
+			} else {
+				if err := swProf.Start(); err != nil {
+					return nil, err
+				}
+				collector.perfSwProfilers[cpu] = &swProf
+				collector.swProfilerCPUMap[&swProf] = cpu
 			}
-			collector.perfSwProfilers[cpu] = &swProf
-			collector.swProfilerCPUMap[&swProf] = cpu
 		}
 
 		if !*perfNoCaProfiler {
@@ -404,15 +417,26 @@ func NewPerfCollector(logger *slog.Logger) (Collector, error) {
 				cacheProfilers,
 			)
 			if err != nil && !cacheProf.HasProfilers() {
-				return nil, err
-			}
-			if err := cacheProf.Start(); err != nil {
-				return nil, err
+				logger.Debug("No perf cache profilers supported on CPU, skipping it", "cpu", cpu, "err", err)
+				cacheErr = err
+			} else {
+				if err := cacheProf.Start(); err != nil {
+					return nil, err
+				}
+				collector.perfCacheProfilers[cpu] = &cacheProf
+				collector.cacheProfilerCPUMap[&cacheProf] = cpu
 			}
-			collector.perfCacheProfilers[cpu] = &cacheProf
-			collector.cacheProfilerCPUMap[&cacheProf] = cpu
 		}
 	}
+	if hwErr != nil && len(collector.perfHwProfilers) == 0 {
+		return nil, hwErr
+	}
+	if swErr != nil && len(collector.perfSwProfilers) == 0 {
+		return nil, swErr
+	}
+	if cacheErr != nil && len(collector.perfCacheProfilers) == 0 {
+		return nil, cacheErr
+	}
 
 	collector.desc = map[string]*prometheus.Desc{
 		"cpucycles_total": prometheus.NewDesc(
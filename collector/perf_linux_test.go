@@ -25,6 +25,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -257,3 +258,25 @@ func TestPerfTracepointFlagToTracepoints(t *testing.T) {
 		})
 	}
 }
+
+func TestPerfUnknownProfilers(t *testing.T) {
+	for _, test := range []struct {
+		flag   string
+		errStr string
+	}{
+		{"--collector.perf.hardware-profilers=CpuCycle", `unknown perf hardware profiler "CpuCycle"`},
+		{"--collector.perf.software-profilers=PageFaults", `unknown perf software profiler "PageFaults"`},
+		{"--collector.perf.cache-profilers=L1DataRead", `unknown perf cache profiler "L1DataRead"`},
+	} {
+		// Strings flags keep their values across parses.
+		*perfHwProfilerFlag, *perfSwProfilerFlag, *perfCaProfilerFlag = nil, nil, nil
+		if _, err := kingpin.CommandLine.Parse([]string{test.flag}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := NewPerfCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		if err == nil || err.Error() != test.errStr {
+			t.Errorf("%s: expected error %q, got %v", test.flag, test.errStr, err)
+		}
+	}
+	*perfHwProfilerFlag, *perfSwProfilerFlag, *perfCaProfilerFlag = nil, nil, nil
+}
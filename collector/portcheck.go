@@ -0,0 +1,122 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noportcheck
+// +build !noportcheck
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const portCheckSubsystem = "port"
+
+var (
+	portCheckTargets = kingpin.Flag("collector.portcheck.targets", "host:port to open a TCP connection to on every scrape. (repeatable)").Strings()
+	portCheckTimeout = kingpin.Flag("collector.portcheck.timeout", "Timeout of a single connection attempt.").Default("2s").Duration()
+)
+
+type portCheckCollector struct {
+	targets   []string
+	timeout   time.Duration
+	duration  typedDesc
+	reachable typedDesc
+	logger    *slog.Logger
+}
+
+func init() {
+	registerCollector("portcheck", defaultDisabled, NewPortCheckCollector)
+}
+
+// NewPortCheckCollector returns a new Collector checking whether the
+// configured TCP ports accept connections.
+func NewPortCheckCollector(logger *slog.Logger) (Collector, error) {
+	if *portCheckTimeout <= 0 {
+		return nil, fmt.Errorf("connect timeout must be positive")
+	}
+	seen := map[string]struct{}{}
+	for _, target := range *portCheckTargets {
+		if _, _, err := net.SplitHostPort(target); err != nil {
+			return nil, fmt.Errorf("invalid port check target %q: %w", target, err)
+		}
+		if _, ok := seen[target]; ok {
+			return nil, fmt.Errorf("duplicate target %q in --collector.portcheck.targets", target)
+		}
+		seen[target] = struct{}{}
+	}
+
+	return &portCheckCollector{
+		targets: *portCheckTargets,
+		timeout: *portCheckTimeout,
+		duration: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, portCheckSubsystem, "connect_duration_seconds"),
+			"Time taken by the last TCP connection attempt to the target.",
+			[]string{"target"}, nil,
+		), prometheus.GaugeValue},
+		reachable: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, portCheckSubsystem, "reachable"),
+			"Whether the last TCP connection attempt to the target succeeded.",
+			[]string{"target"}, nil,
+		), prometheus.GaugeValue},
+		logger: logger,
+	}, nil
+}
+
+func (c *portCheckCollector) Update(ch chan<- prometheus.Metric) error {
+	if len(c.targets) == 0 {
+		return ErrNoData
+	}
+
+	// Connect to all targets in parallel so unreachable ports cost at most
+	// one timeout per scrape.
+	var wg sync.WaitGroup
+	for _, target := range c.targets {
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+			duration, err := c.connect(target)
+			reachable := 1.0
+			if err != nil {
+				c.logger.Debug("TCP connection failed", "target", target, "err", err)
+				reachable = 0
+			}
+			ch <- c.duration.mustNewConstMetric(duration.Seconds(), target)
+			ch <- c.reachable.mustNewConstMetric(reachable, target)
+		}(target)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (c *portCheckCollector) connect(target string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var d net.Dialer
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", target)
+	duration := time.Since(start)
+	if err != nil {
+		return duration, err
+	}
+	return duration, conn.Close()
+}
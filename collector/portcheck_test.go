@@ -0,0 +1,104 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noportcheck
+// +build !noportcheck
+
+package collector
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testPortCheckCollector struct {
+	pc Collector
+}
+
+func (c testPortCheckCollector) Collect(ch chan<- prometheus.Metric) {
+	c.pc.Update(ch)
+}
+
+func (c testPortCheckCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestPortCheckCollector(t *testing.T) {
+	open, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer open.Close()
+	// Take a free port and close it again so nothing listens on it.
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--collector.portcheck.targets", open.Addr().String(),
+		"--collector.portcheck.targets", closed.Addr().String(),
+		"--collector.portcheck.timeout", "500ms",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *portCheckTargets = nil }()
+
+	pc, err := NewPortCheckCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := fmt.Sprintf(`# HELP node_port_reachable Whether the last TCP connection attempt to the target succeeded.
+		# TYPE node_port_reachable gauge
+		node_port_reachable{target=%q} 1
+		node_port_reachable{target=%q} 0
+`, open.Addr(), closed.Addr())
+	if err := testutil.CollectAndCompare(testPortCheckCollector{pc}, strings.NewReader(want), "node_port_reachable"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPortCheckCollectorInvalidTarget(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--collector.portcheck.targets", "localhost"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *portCheckTargets = nil }()
+
+	if _, err := NewPortCheckCollector(slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("want error for target without port")
+	}
+}
+
+func TestPortCheckCollectorDuplicateTarget(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--collector.portcheck.targets", "localhost:22",
+		"--collector.portcheck.targets", "localhost:22",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *portCheckTargets = nil }()
+
+	if _, err := NewPortCheckCollector(slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("want error for duplicate target")
+	}
+}
@@ -18,6 +18,7 @@
 package collector
 
 import (
+	"fmt"
 	"log/slog"
 	"regexp"
 
@@ -41,7 +42,10 @@ func init() {
 }
 
 func NewPowerSupplyClassCollector(logger *slog.Logger) (Collector, error) {
-	pattern := regexp.MustCompile(*powerSupplyClassIgnoredPowerSupplies)
+	pattern, err := regexp.Compile(*powerSupplyClassIgnoredPowerSupplies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --collector.powersupply.ignored-supplies: %w", err)
+	}
 	return &powerSupplyClassCollector{
 		subsystem:      "power_supply",
 		ignoredPattern: pattern,
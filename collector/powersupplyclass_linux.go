@@ -139,6 +139,9 @@ func (c *powerSupplyClassCollector) Update(ch chan<- prometheus.Metric) error {
 		)
 		ch <- prometheus.MustNewConstMetric(fieldDesc, prometheus.GaugeValue, 1.0, values...)
 
+		if powerSupply.Status != "" {
+			pushPowerSupplyStatus(ch, c.subsystem, powerSupply.Name, powerSupply.Status)
+		}
 	}
 
 	return nil
@@ -155,6 +158,33 @@ func pushPowerSupplyMetric(ch chan<- prometheus.Metric, subsystem string, name s
 	ch <- prometheus.MustNewConstMetric(fieldDesc, valueType, value, powerSupplyName)
 }
 
+// powerSupplyStatuses are the values of the status attribute documented in
+// Documentation/ABI/testing/sysfs-class-power.
+var powerSupplyStatuses = []string{"Unknown", "Charging", "Discharging", "Not charging", "Full"}
+
+// pushPowerSupplyStatus exports status as a state set, so that e.g. the time
+// spent discharging can be queried without matching on the info metric.
+func pushPowerSupplyStatus(ch chan<- prometheus.Metric, subsystem string, powerSupplyName string, status string) {
+	fieldDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "status"),
+		"status value of /sys/class/power_supply/<power_supply>.",
+		[]string{"power_supply", "status"},
+		nil,
+	)
+
+	known := false
+	for _, s := range powerSupplyStatuses {
+		value := 0.0
+		if s == status {
+			value, known = 1, true
+		}
+		ch <- prometheus.MustNewConstMetric(fieldDesc, prometheus.GaugeValue, value, powerSupplyName, s)
+	}
+	if !known {
+		ch <- prometheus.MustNewConstMetric(fieldDesc, prometheus.GaugeValue, 1, powerSupplyName, strings.ToValidUTF8(status, "�"))
+	}
+}
+
 func getPowerSupplyClassInfo(ignore *regexp.Regexp) (sysfs.PowerSupplyClass, error) {
 	fs, err := sysfs.NewFS(*sysPath)
 	if err != nil {
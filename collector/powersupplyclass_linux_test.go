@@ -0,0 +1,104 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nopowersupplyclass
+// +build !nopowersupplyclass
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testPowerSupplyClassCollector struct {
+	pc Collector
+}
+
+func (c testPowerSupplyClassCollector) Collect(ch chan<- prometheus.Metric) {
+	c.pc.Update(ch)
+}
+
+// Describe sends no descriptors, as the label names of the info metric
+// depend on the attributes of each power supply.
+func (c testPowerSupplyClassCollector) Describe(ch chan<- *prometheus.Desc) {
+}
+
+func TestPowerSupplyClass(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	pc, err := NewPowerSupplyClassCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_power_supply_capacity capacity value of /sys/class/power_supply/<power_supply>.
+		# TYPE node_power_supply_capacity gauge
+		node_power_supply_capacity{power_supply="BAT0"} 81
+		# HELP node_power_supply_energy_watthour energy_watthour value of /sys/class/power_supply/<power_supply>.
+		# TYPE node_power_supply_energy_watthour gauge
+		node_power_supply_energy_watthour{power_supply="BAT0"} 36.58
+		# HELP node_power_supply_online online value of /sys/class/power_supply/<power_supply>.
+		# TYPE node_power_supply_online gauge
+		node_power_supply_online{power_supply="AC"} 0
+		# HELP node_power_supply_status status value of /sys/class/power_supply/<power_supply>.
+		# TYPE node_power_supply_status gauge
+		node_power_supply_status{power_supply="BAT0",status="Charging"} 0
+		node_power_supply_status{power_supply="BAT0",status="Discharging"} 1
+		node_power_supply_status{power_supply="BAT0",status="Full"} 0
+		node_power_supply_status{power_supply="BAT0",status="Not charging"} 0
+		node_power_supply_status{power_supply="BAT0",status="Unknown"} 0
+		# HELP node_power_supply_voltage_volt voltage_volt value of /sys/class/power_supply/<power_supply>.
+		# TYPE node_power_supply_voltage_volt gauge
+		node_power_supply_voltage_volt{power_supply="BAT0"} 11.66
+`
+	if err := testutil.CollectAndCompare(testPowerSupplyClassCollector{pc}, strings.NewReader(want),
+		"node_power_supply_capacity", "node_power_supply_energy_watthour", "node_power_supply_online",
+		"node_power_supply_status", "node_power_supply_voltage_volt"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPowerSupplyClassIgnoredSupplies(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys", "--collector.powersupply.ignored-supplies", "^BAT"}); err != nil {
+		t.Fatal(err)
+	}
+	pc, err := NewPowerSupplyClassCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Batteries are ignored, attributes the AC adapter lacks are omitted.
+	want := `# HELP node_power_supply_online online value of /sys/class/power_supply/<power_supply>.
+		# TYPE node_power_supply_online gauge
+		node_power_supply_online{power_supply="AC"} 0
+`
+	if err := testutil.CollectAndCompare(testPowerSupplyClassCollector{pc}, strings.NewReader(want),
+		"node_power_supply_capacity", "node_power_supply_online", "node_power_supply_voltage_volt"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := kingpin.CommandLine.Parse([]string{"--collector.powersupply.ignored-supplies", "(BAT"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewPowerSupplyClassCollector(slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("expected error for invalid --collector.powersupply.ignored-supplies")
+	}
+}
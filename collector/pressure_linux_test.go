@@ -0,0 +1,93 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nopressure
+// +build !nopressure
+
+package collector
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testPressureCollector struct {
+	pc Collector
+}
+
+func (c testPressureCollector) Collect(ch chan<- prometheus.Metric) {
+	c.pc.Update(ch)
+}
+
+func (c testPressureCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestPressureStats(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	pc, err := NewPressureStatsCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_pressure_cpu_waiting_seconds_total Total time in seconds that processes have waited for CPU time
+		# TYPE node_pressure_cpu_waiting_seconds_total counter
+		node_pressure_cpu_waiting_seconds_total 14.036781000000001
+		# HELP node_pressure_io_stalled_seconds_total Total time in seconds no process could make progress due to IO congestion
+		# TYPE node_pressure_io_stalled_seconds_total counter
+		node_pressure_io_stalled_seconds_total 159.229614
+		# HELP node_pressure_io_waiting_seconds_total Total time in seconds that processes have waited due to IO congestion
+		# TYPE node_pressure_io_waiting_seconds_total counter
+		node_pressure_io_waiting_seconds_total 159.886802
+		# HELP node_pressure_irq_stalled_seconds_total Total time in seconds no process could make progress due to IRQ congestion
+		# TYPE node_pressure_irq_stalled_seconds_total counter
+		node_pressure_irq_stalled_seconds_total 0.008494
+		# HELP node_pressure_memory_stalled_seconds_total Total time in seconds no process could make progress due to memory congestion
+		# TYPE node_pressure_memory_stalled_seconds_total counter
+		node_pressure_memory_stalled_seconds_total 0
+		# HELP node_pressure_memory_waiting_seconds_total Total time in seconds that processes have waited for memory
+		# TYPE node_pressure_memory_waiting_seconds_total counter
+		node_pressure_memory_waiting_seconds_total 0
+`
+	if err := testutil.CollectAndCompare(testPressureCollector{pc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPressureStatsUnavailable(t *testing.T) {
+	// fixtures_hidepid has no /proc/pressure, like kernels without PSI.
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures_hidepid/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	pc, err := NewPressureStatsCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := make(chan prometheus.Metric, 10)
+	if err := pc.Update(ch); !errors.Is(err, ErrNoData) {
+		t.Fatalf("want ErrNoData, got %v", err)
+	}
+	if len(ch) != 0 {
+		t.Errorf("want no metrics, got %d", len(ch))
+	}
+}
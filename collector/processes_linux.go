@@ -138,6 +138,12 @@ func (c *processCollector) getAllocatedThreads() (int, map[string]int32, int, ma
 		pids++
 		procStates[stat.State]++
 		thread += stat.NumThreads
+		// The only thread of a process is in the state of the process,
+		// don't list its tasks.
+		if stat.NumThreads == 1 {
+			threadStates[stat.State]++
+			continue
+		}
 		err = c.getThreadStates(pid.PID, stat, threadStates)
 		if err != nil {
 			return 0, nil, 0, nil, err
@@ -17,8 +17,12 @@
 package collector
 
 import (
+	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -54,3 +58,52 @@ func TestReadProcessStatus(t *testing.T) {
 		t.Fatalf("Total running pids cannot be greater than %d or equals to 0", maxPid)
 	}
 }
+
+// writeProcStat writes a stat file for pid with threads threads to dir.
+func writeProcStat(tb testing.TB, dir string, pid, threads int, state string) {
+	tb.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		tb.Fatal(err)
+	}
+	stat := fmt.Sprintf("%d (bench) %s 1 %d %d 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 %d 0 24 0 0 18446744073709551615 0 0 0 0 0 0 0 2147483647 0 0 0 0 17 0 0 0 0 0 0 0 0 0 0 0 0 0 0\n", pid, state, pid, pid, threads)
+	if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func BenchmarkGetAllocatedThreads(b *testing.B) {
+	proc := b.TempDir()
+	// Mostly single-threaded processes, with a multi-threaded one every
+	// tenth pid.
+	for pid := 1; pid <= 10000; pid++ {
+		dir := filepath.Join(proc, strconv.Itoa(pid))
+		threads := 1
+		if pid%10 == 0 {
+			threads = 4
+		}
+		writeProcStat(b, dir, pid, threads, "S")
+		for tid := pid; tid < pid+threads; tid++ {
+			writeProcStat(b, filepath.Join(dir, "task", strconv.Itoa(tid)), tid, threads, "S")
+		}
+	}
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", proc}); err != nil {
+		b.Fatal(err)
+	}
+	fs, err := procfs.NewFS(proc)
+	if err != nil {
+		b.Fatal(err)
+	}
+	c := processCollector{fs: fs, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pids, _, threads, threadStates, err := c.getAllocatedThreads()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if pids != 10000 || threads != 13000 || threadStates["S"] != 13000 {
+			b.Fatalf("got %d pids, %d threads and thread states %v", pids, threads, threadStates)
+		}
+	}
+}
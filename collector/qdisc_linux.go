@@ -31,6 +31,7 @@ import (
 type qdiscStatCollector struct {
 	logger       *slog.Logger
 	deviceFilter deviceFilter
+	children     bool
 	bytes        typedDesc
 	packets      typedDesc
 	drops        typedDesc
@@ -46,6 +47,7 @@ var (
 	oldCollectorQdiskDeviceInclude = kingpin.Flag("collector.qdisk.device-include", "DEPRECATED: Use collector.qdisc.device-include").Hidden().String()
 	collectorQdiscDeviceExclude    = kingpin.Flag("collector.qdisc.device-exclude", "Regexp of qdisc devices to exclude (mutually exclusive to device-include).").String()
 	oldCollectorQdiskDeviceExclude = kingpin.Flag("collector.qdisk.device-exclude", "DEPRECATED: Use collector.qdisc.device-exclude").Hidden().String()
+	collectorQdiscIncludeChildren  = kingpin.Flag("collector.qdisc.include-children", "Also report the qdiscs attached to the classes of the root qdiscs, adds handle and parent labels.").Default("false").Bool()
 )
 
 func init() {
@@ -76,47 +78,62 @@ func NewQdiscStatCollector(logger *slog.Logger) (Collector, error) {
 		return nil, fmt.Errorf("collector.qdisc.device-include and collector.qdisc.device-exclude are mutaly exclusive")
 	}
 
+	labels := []string{"device", "kind"}
+	if *collectorQdiscIncludeChildren {
+		labels = append(labels, "handle", "parent")
+	}
+
 	return &qdiscStatCollector{
 		bytes: typedDesc{prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "qdisc", "bytes_total"),
 			"Number of bytes sent.",
-			[]string{"device", "kind"}, nil,
+			labels, nil,
 		), prometheus.CounterValue},
 		packets: typedDesc{prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "qdisc", "packets_total"),
 			"Number of packets sent.",
-			[]string{"device", "kind"}, nil,
+			labels, nil,
 		), prometheus.CounterValue},
 		drops: typedDesc{prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "qdisc", "drops_total"),
 			"Number of packets dropped.",
-			[]string{"device", "kind"}, nil,
+			labels, nil,
 		), prometheus.CounterValue},
 		requeues: typedDesc{prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "qdisc", "requeues_total"),
 			"Number of packets dequeued, not transmitted, and requeued.",
-			[]string{"device", "kind"}, nil,
+			labels, nil,
 		), prometheus.CounterValue},
 		overlimits: typedDesc{prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "qdisc", "overlimits_total"),
 			"Number of overlimit packets.",
-			[]string{"device", "kind"}, nil,
+			labels, nil,
 		), prometheus.CounterValue},
 		qlength: typedDesc{prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "qdisc", "current_queue_length"),
 			"Number of packets currently in queue to be sent.",
-			[]string{"device", "kind"}, nil,
+			labels, nil,
 		), prometheus.GaugeValue},
 		backlog: typedDesc{prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "qdisc", "backlog"),
 			"Number of bytes currently in queue to be sent.",
-			[]string{"device", "kind"}, nil,
+			labels, nil,
 		), prometheus.GaugeValue},
 		logger:       logger,
 		deviceFilter: newDeviceFilter(*collectorQdiscDeviceExclude, *collectorQdiscDeviceInclude),
+		children:     *collectorQdiscIncludeChildren,
 	}, nil
 }
 
+// qdiscHandle formats a qdisc handle the way tc(8) does, e.g. "8001:" or
+// "1:2".
+func qdiscHandle(handle uint32) string {
+	if handle&0xffff == 0 {
+		return fmt.Sprintf("%x:", handle>>16)
+	}
+	return fmt.Sprintf("%x:%x", handle>>16, handle&0xffff)
+}
+
 func testQdiscGet(fixtures string) ([]qdisc.QdiscInfo, error) {
 	var res []qdisc.QdiscInfo
 
@@ -146,8 +163,8 @@ func (c *qdiscStatCollector) Update(ch chan<- prometheus.Metric) error {
 	}
 
 	for _, msg := range msgs {
-		// Only report root qdisc information.
-		if msg.Parent != 0 {
+		// Only report root qdisc information, unless asked for the children.
+		if msg.Parent != 0 && !c.children {
 			continue
 		}
 
@@ -155,13 +172,22 @@ func (c *qdiscStatCollector) Update(ch chan<- prometheus.Metric) error {
 			continue
 		}
 
-		ch <- c.bytes.mustNewConstMetric(float64(msg.Bytes), msg.IfaceName, msg.Kind)
-		ch <- c.packets.mustNewConstMetric(float64(msg.Packets), msg.IfaceName, msg.Kind)
-		ch <- c.drops.mustNewConstMetric(float64(msg.Drops), msg.IfaceName, msg.Kind)
-		ch <- c.requeues.mustNewConstMetric(float64(msg.Requeues), msg.IfaceName, msg.Kind)
-		ch <- c.overlimits.mustNewConstMetric(float64(msg.Overlimits), msg.IfaceName, msg.Kind)
-		ch <- c.qlength.mustNewConstMetric(float64(msg.Qlen), msg.IfaceName, msg.Kind)
-		ch <- c.backlog.mustNewConstMetric(float64(msg.Backlog), msg.IfaceName, msg.Kind)
+		labels := []string{msg.IfaceName, msg.Kind}
+		if c.children {
+			parent := "root"
+			if msg.Parent != 0 {
+				parent = qdiscHandle(msg.Parent)
+			}
+			labels = append(labels, qdiscHandle(msg.Handle), parent)
+		}
+
+		ch <- c.bytes.mustNewConstMetric(float64(msg.Bytes), labels...)
+		ch <- c.packets.mustNewConstMetric(float64(msg.Packets), labels...)
+		ch <- c.drops.mustNewConstMetric(float64(msg.Drops), labels...)
+		ch <- c.requeues.mustNewConstMetric(float64(msg.Requeues), labels...)
+		ch <- c.overlimits.mustNewConstMetric(float64(msg.Overlimits), labels...)
+		ch <- c.qlength.mustNewConstMetric(float64(msg.Qlen), labels...)
+		ch <- c.backlog.mustNewConstMetric(float64(msg.Backlog), labels...)
 	}
 
 	return nil
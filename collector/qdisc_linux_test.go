@@ -0,0 +1,92 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noqdisc
+// +build !noqdisc
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testQdiscCollector struct {
+	qc Collector
+}
+
+func (c testQdiscCollector) Collect(ch chan<- prometheus.Metric) {
+	c.qc.Update(ch)
+}
+
+func (c testQdiscCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestQdiscChildren(t *testing.T) {
+	// An mq root qdisc with an fq_codel qdisc per hardware queue.
+	fixtures := t.TempDir()
+	results := `[
+		{"IfaceName": "eth0", "Parent": 0, "Handle": 65536, "Kind": "mq", "Drops": 7},
+		{"IfaceName": "eth0", "Parent": 65537, "Handle": 0, "Kind": "fq_codel", "Drops": 3},
+		{"IfaceName": "eth0", "Parent": 65538, "Handle": 0, "Kind": "fq_codel", "Drops": 4}
+	]`
+	if err := os.WriteFile(filepath.Join(fixtures, "results.json"), []byte(results), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "root only",
+			want: `# HELP node_qdisc_drops_total Number of packets dropped.
+				# TYPE node_qdisc_drops_total counter
+				node_qdisc_drops_total{device="eth0",kind="mq"} 7
+`,
+		},
+		{
+			name: "children",
+			args: []string{"--collector.qdisc.include-children"},
+			want: `# HELP node_qdisc_drops_total Number of packets dropped.
+				# TYPE node_qdisc_drops_total counter
+				node_qdisc_drops_total{device="eth0",handle="0:",kind="fq_codel",parent="1:1"} 3
+				node_qdisc_drops_total{device="eth0",handle="0:",kind="fq_codel",parent="1:2"} 4
+				node_qdisc_drops_total{device="eth0",handle="1:",kind="mq",parent="root"} 7
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := kingpin.CommandLine.Parse(append([]string{"--collector.qdisc.fixtures", fixtures}, test.args...)); err != nil {
+				t.Fatal(err)
+			}
+			qc, err := NewQdiscStatCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := testutil.CollectAndCompare(testQdiscCollector{qc}, strings.NewReader(test.want), "node_qdisc_drops_total"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
@@ -35,6 +37,18 @@ type raplCollector struct {
 	logger *slog.Logger
 
 	joulesMetricDesc *prometheus.Desc
+
+	mtx sync.Mutex
+	// energy holds the accumulated energy of each zone by path, as
+	// energy_uj wraps around at max_energy_range_uj.
+	energy map[string]*raplEnergy
+	// permissionWarned is set once the energy_uj files turned out to be
+	// unreadable, which is the default for non-root users since Linux 5.10.
+	permissionWarned atomic.Bool
+}
+
+type raplEnergy struct {
+	last, total uint64
 }
 
 func init() {
@@ -63,6 +77,7 @@ func NewRaplCollector(logger *slog.Logger) (Collector, error) {
 		fs:               fs,
 		logger:           logger,
 		joulesMetricDesc: joulesMetricDesc,
+		energy:           map[string]*raplEnergy{},
 	}
 	return &collector, nil
 }
@@ -77,23 +92,25 @@ func (c *raplCollector) Update(ch chan<- prometheus.Metric) error {
 			return ErrNoData
 		}
 		if errors.Is(err, os.ErrPermission) {
-			c.logger.Debug("Can't access powercap files", "err", err)
+			c.logPermissionError("Can't access powercap files", err)
 			return ErrNoData
 		}
 		return fmt.Errorf("failed to retrieve rapl stats: %w", err)
 	}
 
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
 	for _, rz := range zones {
 		microJoules, err := rz.GetEnergyMicrojoules()
 		if err != nil {
 			if errors.Is(err, os.ErrPermission) {
-				c.logger.Debug("Can't access energy_uj file", "zone", rz, "err", err)
+				c.logPermissionError("Can't access energy_uj file", err, "zone", rz.Path)
 				return ErrNoData
 			}
 			return err
 		}
 
-		joules := float64(microJoules) / 1000000.0
+		joules := float64(c.accumulate(rz, microJoules)) / 1000000.0
 
 		if *raplZoneLabel {
 			ch <- c.joulesMetricWithZoneLabel(rz, joules)
@@ -104,6 +121,39 @@ func (c *raplCollector) Update(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
+// logPermissionError logs a permission error as warning the first time and
+// at debug level afterwards, as it won't go away without a restart as root.
+func (c *raplCollector) logPermissionError(msg string, err error, args ...any) {
+	args = append(args, "err", err)
+	if c.permissionWarned.Swap(true) {
+		c.logger.Debug(msg, args...)
+		return
+	}
+	c.logger.Warn(msg, args...)
+}
+
+// accumulate returns the energy consumed by the zone in microjoules, which
+// starts at the first reading of energy_uj and keeps counting up when
+// energy_uj wraps around. The caller must hold mtx.
+func (c *raplCollector) accumulate(rz sysfs.RaplZone, microJoules uint64) uint64 {
+	e, ok := c.energy[rz.Path]
+	if !ok {
+		c.energy[rz.Path] = &raplEnergy{last: microJoules, total: microJoules}
+		return microJoules
+	}
+	switch {
+	case microJoules >= e.last:
+		e.total += microJoules - e.last
+	case rz.MaxMicrojoules >= e.last:
+		e.total += rz.MaxMicrojoules - e.last + microJoules
+	default:
+		// Without a valid range, count from zero.
+		e.total += microJoules
+	}
+	e.last = microJoules
+	return e.total
+}
+
 func (c *raplCollector) joulesMetric(z sysfs.RaplZone, v float64) prometheus.Metric {
 	index := strconv.Itoa(z.Index)
 	descriptor := prometheus.NewDesc(
@@ -0,0 +1,86 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !norapl
+// +build !norapl
+
+package collector
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testRaplCollector struct {
+	rc Collector
+}
+
+func (c testRaplCollector) Collect(ch chan<- prometheus.Metric) {
+	c.rc.Update(ch)
+}
+
+func (c testRaplCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestRaplWraparound(t *testing.T) {
+	sys := t.TempDir()
+	zone := filepath.Join(sys, "class/powercap/intel-rapl:0")
+	if err := os.MkdirAll(zone, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(zone, name), []byte(content+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("name", "package-0")
+	writeFile("max_energy_range_uj", "10000000")
+
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", sys}); err != nil {
+		t.Fatal(err)
+	}
+	rc, err := NewRaplCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, step := range []struct {
+		energy string
+		want   float64
+	}{
+		{"9000000", 9},
+		{"9500000", 9.5},
+		// Wrapped around at 10 J.
+		{"1000000", 11},
+		{"2000000", 12},
+	} {
+		writeFile("energy_uj", step.energy)
+		want := fmt.Sprintf(`# HELP node_rapl_package_joules_total Current RAPL package value in joules
+			# TYPE node_rapl_package_joules_total counter
+			node_rapl_package_joules_total{index="0",path=%q} %g
+`, zone, step.want)
+		if err := testutil.CollectAndCompare(testRaplCollector{rc}, strings.NewReader(want)); err != nil {
+			t.Fatalf("energy_uj %s: %v", step.energy, err)
+		}
+	}
+}
@@ -17,10 +17,13 @@
 package collector
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs"
@@ -28,6 +31,12 @@ import (
 
 const nsPerSec = 1e9
 
+// supportedSchedstatVersions are the /proc/schedstat versions whose cpu lines
+// report the running and waiting times in nanoseconds, see
+// Documentation/scheduler/sched-stats.rst. Later versions only changed the
+// domain lines.
+var supportedSchedstatVersions = map[int]bool{15: true, 16: true, 17: true}
+
 var (
 	runningSecondsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "schedstat", "running_seconds_total"),
@@ -71,7 +80,7 @@ func init() {
 }
 
 func (c *schedstatCollector) Update(ch chan<- prometheus.Metric) error {
-	stats, err := c.fs.Schedstat()
+	version, err := schedstatVersion(procFilePath("schedstat"))
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			c.logger.Debug("schedstat file does not exist")
@@ -79,6 +88,15 @@ func (c *schedstatCollector) Update(ch chan<- prometheus.Metric) error {
 		}
 		return err
 	}
+	// The fields of other versions could have different meanings or units.
+	if !supportedSchedstatVersions[version] {
+		return fmt.Errorf("unsupported /proc/schedstat version %d", version)
+	}
+
+	stats, err := c.fs.Schedstat()
+	if err != nil {
+		return err
+	}
 
 	for _, cpu := range stats.CPUs {
 		ch <- prometheus.MustNewConstMetric(
@@ -105,3 +123,30 @@ func (c *schedstatCollector) Update(ch chan<- prometheus.Metric) error {
 
 	return nil
 }
+
+// schedstatVersion returns the version from the first line of
+// /proc/schedstat.
+func schedstatVersion(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("empty %s", path)
+	}
+	v, ok := strings.CutPrefix(scanner.Text(), "version ")
+	if !ok {
+		return 0, fmt.Errorf("missing version in %s: %q", path, scanner.Text())
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return 0, fmt.Errorf("invalid version in %s: %w", path, err)
+	}
+	return version, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noshedstat
+// +build !noshedstat
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSchedstatVersion(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "version 16",
+			content: "version 16\ntimestamp 4294813318\ncpu0 0 0 0 0 0 0 2045936778163039 343796328169361 4767485306\n",
+		},
+		{
+			name:    "unsupported version",
+			content: "version 14\ntimestamp 4294813318\ncpu0 0 0 0 0 0 0 0 2045936778163039 343796328169361 4767485306\n",
+			wantErr: "unsupported /proc/schedstat version 14",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			proc := t.TempDir()
+			if err := os.WriteFile(filepath.Join(proc, "schedstat"), []byte(test.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", proc}); err != nil {
+				t.Fatal(err)
+			}
+			c, err := NewSchedstatCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ch := make(chan prometheus.Metric, 10)
+			err = c.Update(ch)
+			close(ch)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("want error %q, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(ch); n != 3 {
+				t.Errorf("want 3 metrics, got %d", n)
+			}
+		})
+	}
+}
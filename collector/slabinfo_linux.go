@@ -17,8 +17,14 @@
 package collector
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
@@ -28,6 +34,7 @@ import (
 var (
 	slabNameInclude = kingpin.Flag("collector.slabinfo.slabs-include", "Regexp of slabs to include in slabinfo collector.").Default(".*").String()
 	slabNameExclude = kingpin.Flag("collector.slabinfo.slabs-exclude", "Regexp of slabs to exclude in slabinfo collector.").Default("").String()
+	slabTopN        = kingpin.Flag("collector.slabinfo.top-n", "Only export the N largest slabs by size, 0 exports all of them.").Default("0").Int()
 )
 
 type slabinfoCollector struct {
@@ -36,6 +43,9 @@ type slabinfoCollector struct {
 	subsystem      string
 	labels         []string
 	slabNameFilter deviceFilter
+	topN           int
+
+	permissionWarning sync.Once
 }
 
 func init() {
@@ -53,29 +63,80 @@ func NewSlabinfoCollector(logger *slog.Logger) (Collector, error) {
 		subsystem:      "slabinfo",
 		labels:         []string{"slab"},
 		slabNameFilter: newDeviceFilter(*slabNameExclude, *slabNameInclude),
+		topN:           *slabTopN,
 	}, nil
 }
 
 func (c *slabinfoCollector) Update(ch chan<- prometheus.Metric) error {
 	slabinfo, err := c.fs.SlabInfo()
 	if err != nil {
+		// /proc/slabinfo is only readable by root.
+		if errors.Is(err, os.ErrPermission) {
+			c.permissionWarning.Do(func() {
+				c.logger.Warn("slabinfo is not readable, the slabinfo collector needs to run as root", "err", err)
+			})
+			return ErrNoData
+		}
+		if version, verr := slabinfoVersion(procFilePath("slabinfo")); verr == nil && version != "2.1" {
+			return fmt.Errorf("unsupported slabinfo version %s: %w", version, err)
+		}
 		return fmt.Errorf("couldn't get %s: %w", c.subsystem, err)
 	}
 
+	var slabs []*procfs.Slab
 	for _, slab := range slabinfo.Slabs {
 		if c.slabNameFilter.ignored(slab.Name) {
 			continue
 		}
+		slabs = append(slabs, slab)
+	}
+	if c.topN > 0 && len(slabs) > c.topN {
+		sort.SliceStable(slabs, func(i, j int) bool {
+			return slabSizeBytes(slabs[i]) > slabSizeBytes(slabs[j])
+		})
+		slabs = slabs[:c.topN]
+	}
+
+	for _, slab := range slabs {
 		ch <- c.activeObjects(slab.Name, slab.ObjActive)
 		ch <- c.objects(slab.Name, slab.ObjNum)
 		ch <- c.objectSizeBytes(slab.Name, slab.ObjSize)
 		ch <- c.objectsPerSlab(slab.Name, slab.ObjPerSlab)
 		ch <- c.pagesPerSlab(slab.Name, slab.PagesPerSlab)
+		ch <- c.sizeBytes(slab.Name, slabSizeBytes(slab))
 	}
 
 	return nil
 }
 
+// slabSizeBytes returns the memory taken by the allocated objects of slab.
+func slabSizeBytes(slab *procfs.Slab) int64 {
+	return slab.ObjNum * slab.ObjSize
+}
+
+// slabinfoVersion returns the version in the "slabinfo - version: 2.1"
+// header of path.
+func slabinfoVersion(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", errors.New("slabinfo is empty")
+	}
+	version, ok := strings.CutPrefix(scanner.Text(), "slabinfo - version: ")
+	if !ok {
+		return "", fmt.Errorf("invalid slabinfo header %q", scanner.Text())
+	}
+	return strings.TrimSpace(version), nil
+}
+
 func (c *slabinfoCollector) activeObjects(label string, val int64) prometheus.Metric {
 	desc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, c.subsystem, "active_objects"),
@@ -130,3 +191,14 @@ func (c *slabinfoCollector) pagesPerSlab(label string, val int64) prometheus.Met
 		desc, prometheus.GaugeValue, float64(val), label,
 	)
 }
+
+func (c *slabinfoCollector) sizeBytes(label string, val int64) prometheus.Metric {
+	desc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, c.subsystem, "size_bytes"),
+		"The memory taken by the allocated objects of this slab, in bytes.",
+		c.labels, nil)
+
+	return prometheus.MustNewConstMetric(
+		desc, prometheus.GaugeValue, float64(val), label,
+	)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noslabinfo
+// +build !noslabinfo
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testSlabinfoCollector struct {
+	sc Collector
+}
+
+func (c testSlabinfoCollector) Collect(ch chan<- prometheus.Metric) {
+	c.sc.Update(ch)
+}
+
+func (c testSlabinfoCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestSlabinfoTopN(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc", "--collector.slabinfo.top-n", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := NewSlabinfoCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_slabinfo_objects The total number of allocated objects (i.e., objects that are both in use and not in use).
+		# TYPE node_slabinfo_objects gauge
+		node_slabinfo_objects{slab="dmaengine-unmap-128"} 1320
+		node_slabinfo_objects{slab="kmalloc-8192"} 148
+		# HELP node_slabinfo_size_bytes The memory taken by the allocated objects of this slab, in bytes.
+		# TYPE node_slabinfo_size_bytes gauge
+		node_slabinfo_size_bytes{slab="dmaengine-unmap-128"} 1.43616e+06
+		node_slabinfo_size_bytes{slab="kmalloc-8192"} 1.212416e+06
+`
+	if err := testutil.CollectAndCompare(testSlabinfoCollector{sc}, strings.NewReader(want),
+		"node_slabinfo_objects", "node_slabinfo_size_bytes"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSlabinfoUnsupportedVersion(t *testing.T) {
+	proc := t.TempDir()
+	slabinfo := "slabinfo - version: 3.0\n# name <active_objs> <num_objs> <objsize>\nkmem_cache 320 320 256\n"
+	if err := os.WriteFile(filepath.Join(proc, "slabinfo"), []byte(slabinfo), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", proc}); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := NewSlabinfoCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sc.Update(make(chan prometheus.Metric, 10))
+	if err == nil || !strings.Contains(err.Error(), "unsupported slabinfo version 3.0") {
+		t.Fatalf("want unsupported version error, got %v", err)
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs"
@@ -88,6 +90,55 @@ func (c *sockStatCollector) Update(ch chan<- prometheus.Metric) error {
 		c.update(ch, s.isIPv6, s.stat)
 	}
 
+	// The memory limits apply to both IPv4 and IPv6 sockets.
+	for _, l := range []struct{ protocol, file string }{
+		{"TCP", "sys/net/ipv4/tcp_mem"},
+		{"UDP", "sys/net/ipv4/udp_mem"},
+	} {
+		// The limits are optional, they don't fail the sockstat metrics.
+		if err := c.updateMemLimits(ch, l.protocol, procFilePath(l.file)); err != nil {
+			c.logger.Debug("couldn't read socket memory limits, skipping", "protocol", l.protocol, "err", err)
+		}
+	}
+
+	return nil
+}
+
+// updateMemLimits exports the min, pressure and max thresholds in pages
+// from the tcp_mem or udp_mem sysctl at path in bytes, to compare with the
+// <protocol>_mem_bytes usage.
+func (c *sockStatCollector) updateMemLimits(ch chan<- prometheus.Metric, protocol, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			c.logger.Debug("socket memory limits not found, skipping", "path", path)
+			return nil
+		}
+		return fmt.Errorf("failed to read socket memory limits: %w", err)
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) != 3 {
+		return fmt.Errorf("expected 3 values in %s, got %q", path, content)
+	}
+
+	limits := []string{"min", "pressure", "max"}
+	pages := make([]uint64, len(limits))
+	for i, limit := range limits {
+		pages[i], err = strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s limit in %s: %w", limit, path, err)
+		}
+	}
+
+	desc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sockStatSubsystem, protocol+"_mem_limit_bytes"),
+		fmt.Sprintf("Memory usage thresholds of all %s sockets, from the %s sysctl.", protocol, strings.ToLower(protocol)+"_mem"),
+		[]string{"limit"},
+		nil,
+	)
+	for i, limit := range limits {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(pages[i])*float64(pageSize), limit)
+	}
 	return nil
 }
 
@@ -0,0 +1,116 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nosockstat
+// +build !nosockstat
+
+package collector
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testSockStatCollector struct {
+	sc Collector
+}
+
+func (c testSockStatCollector) Collect(ch chan<- prometheus.Metric) {
+	c.sc.Update(ch)
+}
+
+func (c testSockStatCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestSockStat(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := NewSockStatCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := fmt.Sprintf(`# HELP node_sockstat_TCP_inuse Number of TCP sockets in state inuse.
+		# TYPE node_sockstat_TCP_inuse gauge
+		node_sockstat_TCP_inuse 4
+		# HELP node_sockstat_TCP_mem_bytes Number of TCP sockets in state mem_bytes.
+		# TYPE node_sockstat_TCP_mem_bytes gauge
+		node_sockstat_TCP_mem_bytes %d
+		# HELP node_sockstat_TCP_mem_limit_bytes Memory usage thresholds of all TCP sockets, from the tcp_mem sysctl.
+		# TYPE node_sockstat_TCP_mem_limit_bytes gauge
+		node_sockstat_TCP_mem_limit_bytes{limit="max"} %d
+		node_sockstat_TCP_mem_limit_bytes{limit="min"} %d
+		node_sockstat_TCP_mem_limit_bytes{limit="pressure"} %d
+		# HELP node_sockstat_sockets_used Number of IPv4 sockets in use.
+		# TYPE node_sockstat_sockets_used gauge
+		node_sockstat_sockets_used 229
+`, pageSize, 44016*pageSize, 22008*pageSize, 29346*pageSize)
+	if err := testutil.CollectAndCompare(testSockStatCollector{sc}, strings.NewReader(want),
+		"node_sockstat_TCP_inuse", "node_sockstat_TCP_mem_bytes", "node_sockstat_TCP_mem_limit_bytes", "node_sockstat_sockets_used",
+		// Not in the fixture, so not exported.
+		"node_sockstat_SCTP_inuse"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSockStatInvalidMemLimits(t *testing.T) {
+	proc := t.TempDir()
+	sockstat, err := os.ReadFile("fixtures/proc/net/sockstat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for file, content := range map[string][]byte{
+		"net/sockstat":         sockstat,
+		"sys/net/ipv4/tcp_mem": []byte("22008\t29346\n"),
+	} {
+		path := filepath.Join(proc, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", proc}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *procPath = "fixtures/proc" }()
+	sc, err := NewSockStatCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The invalid tcp_mem only skips the limits.
+	if err := sc.Update(make(chan prometheus.Metric, 100)); err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+	want := `# HELP node_sockstat_sockets_used Number of IPv4 sockets in use.
+		# TYPE node_sockstat_sockets_used gauge
+		node_sockstat_sockets_used 229
+`
+	if err := testutil.CollectAndCompare(testSockStatCollector{sc}, strings.NewReader(want),
+		"node_sockstat_sockets_used", "node_sockstat_TCP_mem_limit_bytes"); err != nil {
+		t.Fatal(err)
+	}
+}
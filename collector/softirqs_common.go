@@ -20,14 +20,18 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs"
 )
 
+var softirqsAggregate = kingpin.Flag("collector.softirqs.aggregate", "Sum softirq counts over all CPUs instead of exposing them per CPU.").Bool()
+
 type softirqsCollector struct {
-	fs     procfs.FS
-	desc   typedDesc
-	logger *slog.Logger
+	fs        procfs.FS
+	desc      typedDesc
+	aggregate bool
+	logger    *slog.Logger
 }
 
 func init() {
@@ -41,11 +45,18 @@ func NewSoftirqsCollector(logger *slog.Logger) (Collector, error) {
 		"Softirq counts per CPU.",
 		softirqLabelNames, nil,
 	), prometheus.CounterValue}
+	if *softirqsAggregate {
+		desc = typedDesc{prometheus.NewDesc(
+			namespace+"_softirqs_functions_total",
+			"Softirq counts summed over all CPUs.",
+			[]string{"type"}, nil,
+		), prometheus.CounterValue}
+	}
 
 	fs, err := procfs.NewFS(*procPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open procfs: %w", err)
 	}
 
-	return &softirqsCollector{fs, desc, logger}, nil
+	return &softirqsCollector{fs, desc, *softirqsAggregate, logger}, nil
 }
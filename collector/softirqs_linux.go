@@ -33,35 +33,34 @@ func (c *softirqsCollector) Update(ch chan<- prometheus.Metric) (err error) {
 		return fmt.Errorf("couldn't get softirqs: %w", err)
 	}
 
-	for cpuNo, value := range softirqs.Hi {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "HI")
-	}
-	for cpuNo, value := range softirqs.Timer {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "TIMER")
-	}
-	for cpuNo, value := range softirqs.NetTx {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "NET_TX")
-	}
-	for cpuNo, value := range softirqs.NetRx {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "NET_RX")
-	}
-	for cpuNo, value := range softirqs.Block {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "BLOCK")
-	}
-	for cpuNo, value := range softirqs.IRQPoll {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "IRQ_POLL")
-	}
-	for cpuNo, value := range softirqs.Tasklet {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "TASKLET")
-	}
-	for cpuNo, value := range softirqs.Sched {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "SCHED")
-	}
-	for cpuNo, value := range softirqs.HRTimer {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "HRTIMER")
-	}
-	for cpuNo, value := range softirqs.RCU {
-		ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), "RCU")
+	// The number of columns follows the number of possible CPUs, which
+	// procfs already takes care of when parsing the header.
+	for _, softirq := range []struct {
+		name   string
+		values []uint64
+	}{
+		{"HI", softirqs.Hi},
+		{"TIMER", softirqs.Timer},
+		{"NET_TX", softirqs.NetTx},
+		{"NET_RX", softirqs.NetRx},
+		{"BLOCK", softirqs.Block},
+		{"IRQ_POLL", softirqs.IRQPoll},
+		{"TASKLET", softirqs.Tasklet},
+		{"SCHED", softirqs.Sched},
+		{"HRTIMER", softirqs.HRTimer},
+		{"RCU", softirqs.RCU},
+	} {
+		if c.aggregate {
+			var total uint64
+			for _, value := range softirq.values {
+				total += value
+			}
+			ch <- c.desc.mustNewConstMetric(float64(total), softirq.name)
+			continue
+		}
+		for cpuNo, value := range softirq.values {
+			ch <- c.desc.mustNewConstMetric(float64(value), strconv.Itoa(cpuNo), softirq.name)
+		}
 	}
 
 	return err
@@ -0,0 +1,72 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nosoftirqs
+// +build !nosoftirqs
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testSoftirqsCollector struct {
+	sc Collector
+}
+
+func (c testSoftirqsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.sc.Update(ch)
+}
+
+func (c testSoftirqsCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestSoftirqsAggregate(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--path.procfs", "fixtures/proc",
+		"--collector.softirqs.aggregate",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *softirqsAggregate = false }()
+
+	sc, err := NewSoftirqsCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_softirqs_functions_total Softirq counts summed over all CPUs.
+		# TYPE node_softirqs_functions_total counter
+		node_softirqs_functions_total{type="BLOCK"} 47891
+		node_softirqs_functions_total{type="HI"} 8
+		node_softirqs_functions_total{type="HRTIMER"} 386
+		node_softirqs_functions_total{type="IRQ_POLL"} 0
+		node_softirqs_functions_total{type="NET_RX"} 147574
+		node_softirqs_functions_total{type="NET_TX"} 4731
+		node_softirqs_functions_total{type="RCU"} 302560
+		node_softirqs_functions_total{type="SCHED"} 531747
+		node_softirqs_functions_total{type="TASKLET"} 2271
+		node_softirqs_functions_total{type="TIMER"} 532533
+`
+	if err := testutil.CollectAndCompare(testSoftirqsCollector{sc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -129,18 +129,29 @@ func (c *softnetCollector) Update(ch chan<- prometheus.Metric) error {
 			float64(cpuStats.CPUCollision),
 			cpu,
 		)
+
+		// Columns were added over time, only expose the ones the kernel has.
+		if cpuStats.Width < 10 {
+			continue
+		}
 		ch <- prometheus.MustNewConstMetric(
 			c.receivedRps,
 			prometheus.CounterValue,
 			float64(cpuStats.ReceivedRps),
 			cpu,
 		)
+		if cpuStats.Width < 11 {
+			continue
+		}
 		ch <- prometheus.MustNewConstMetric(
 			c.flowLimitCount,
 			prometheus.CounterValue,
 			float64(cpuStats.FlowLimitCount),
 			cpu,
 		)
+		if cpuStats.Width < 13 {
+			continue
+		}
 		ch <- prometheus.MustNewConstMetric(
 			c.softnetBacklogLen,
 			prometheus.GaugeValue,
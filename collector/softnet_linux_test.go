@@ -0,0 +1,135 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nosoftnet
+// +build !nosoftnet
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testSoftnetCollector struct {
+	sc Collector
+}
+
+func (c testSoftnetCollector) Collect(ch chan<- prometheus.Metric) {
+	c.sc.Update(ch)
+}
+
+func (c testSoftnetCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestSoftnet(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			// Linux 3.x has neither flow_limit_count nor backlog_len and
+			// no CPU index column.
+			name: "10 columns",
+			content: "00000a10 00000001 00000002 00000000 00000000 00000000 00000000 00000000 00000000 00000003\n" +
+				"00000b20 00000000 00000004 00000000 00000000 00000000 00000000 00000000 00000000 00000005\n",
+			want: `# HELP node_softnet_dropped_total Number of dropped packets
+				# TYPE node_softnet_dropped_total counter
+				node_softnet_dropped_total{cpu="0"} 1
+				node_softnet_dropped_total{cpu="1"} 0
+				# HELP node_softnet_processed_total Number of processed packets
+				# TYPE node_softnet_processed_total counter
+				node_softnet_processed_total{cpu="0"} 2576
+				node_softnet_processed_total{cpu="1"} 2848
+				# HELP node_softnet_received_rps_total Number of times cpu woken up received_rps
+				# TYPE node_softnet_received_rps_total counter
+				node_softnet_received_rps_total{cpu="0"} 3
+				node_softnet_received_rps_total{cpu="1"} 5
+				# HELP node_softnet_times_squeezed_total Number of times processing packets ran out of quota
+				# TYPE node_softnet_times_squeezed_total counter
+				node_softnet_times_squeezed_total{cpu="0"} 2
+				node_softnet_times_squeezed_total{cpu="1"} 4
+`,
+		},
+		{
+			// Linux 5.10+ has backlog_len and the CPU index, which skips
+			// offline CPUs.
+			name: "13 columns",
+			content: "00000a10 00000001 00000002 00000000 00000000 00000000 00000000 00000000 00000000 00000003 00000006 00000007 00000000\n" +
+				"00000b20 00000000 00000004 00000000 00000000 00000000 00000000 00000000 00000000 00000005 00000000 00000000 00000002\n",
+			want: `# HELP node_softnet_backlog_len Softnet backlog status
+				# TYPE node_softnet_backlog_len gauge
+				node_softnet_backlog_len{cpu="0"} 7
+				node_softnet_backlog_len{cpu="2"} 0
+				# HELP node_softnet_dropped_total Number of dropped packets
+				# TYPE node_softnet_dropped_total counter
+				node_softnet_dropped_total{cpu="0"} 1
+				node_softnet_dropped_total{cpu="2"} 0
+				# HELP node_softnet_flow_limit_count_total Number of times flow limit has been reached
+				# TYPE node_softnet_flow_limit_count_total counter
+				node_softnet_flow_limit_count_total{cpu="0"} 6
+				node_softnet_flow_limit_count_total{cpu="2"} 0
+				# HELP node_softnet_processed_total Number of processed packets
+				# TYPE node_softnet_processed_total counter
+				node_softnet_processed_total{cpu="0"} 2576
+				node_softnet_processed_total{cpu="2"} 2848
+				# HELP node_softnet_received_rps_total Number of times cpu woken up received_rps
+				# TYPE node_softnet_received_rps_total counter
+				node_softnet_received_rps_total{cpu="0"} 3
+				node_softnet_received_rps_total{cpu="2"} 5
+				# HELP node_softnet_times_squeezed_total Number of times processing packets ran out of quota
+				# TYPE node_softnet_times_squeezed_total counter
+				node_softnet_times_squeezed_total{cpu="0"} 2
+				node_softnet_times_squeezed_total{cpu="2"} 4
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			proc := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(proc, "net"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(proc, "net", "softnet_stat"), []byte(tc.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", proc}); err != nil {
+				t.Fatal(err)
+			}
+			sc, err := NewSoftnetCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := testutil.CollectAndCompare(testSoftnetCollector{sc}, strings.NewReader(tc.want),
+				"node_softnet_backlog_len",
+				"node_softnet_dropped_total",
+				"node_softnet_flow_limit_count_total",
+				"node_softnet_processed_total",
+				"node_softnet_received_rps_total",
+				"node_softnet_times_squeezed_total",
+			); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nostat
+// +build !nostat
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testStatCollector struct {
+	sc Collector
+}
+
+func (c testStatCollector) Collect(ch chan<- prometheus.Metric) {
+	c.sc.Update(ch)
+}
+
+func (c testStatCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestStat(t *testing.T) {
+	*procPath = "fixtures/proc"
+	sc, err := NewStatCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_boot_time_seconds Node boot time, in unixtime.
+		# TYPE node_boot_time_seconds gauge
+		node_boot_time_seconds 1.418183276e+09
+		# HELP node_context_switches_total Total number of context switches.
+		# TYPE node_context_switches_total counter
+		node_context_switches_total 3.8014093e+07
+		# HELP node_forks_total Total number of forks.
+		# TYPE node_forks_total counter
+		node_forks_total 26442
+		# HELP node_intr_total Total number of interrupts serviced.
+		# TYPE node_intr_total counter
+		node_intr_total 8.885917e+06
+		# HELP node_procs_blocked Number of processes blocked waiting for I/O to complete.
+		# TYPE node_procs_blocked gauge
+		node_procs_blocked 0
+		# HELP node_procs_running Number of processes in runnable state.
+		# TYPE node_procs_running gauge
+		node_procs_running 2
+`
+	if err := testutil.CollectAndCompare(testStatCollector{sc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
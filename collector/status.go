@@ -0,0 +1,73 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// CollectorStatus describes a registered collector and the outcome of its
+// last Update.
+type CollectorStatus struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// The remaining fields are only set once the collector has run.
+	LastScrape          *time.Time `json:"last_scrape,omitempty"`
+	LastDurationSeconds *float64   `json:"last_duration_seconds,omitempty"`
+	LastSuccess         *bool      `json:"last_success,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+type scrapeResult struct {
+	time     time.Time
+	duration time.Duration
+	err      error
+}
+
+var (
+	lastScrapeResultsMtx sync.Mutex
+	lastScrapeResults    = make(map[string]scrapeResult)
+)
+
+func recordScrapeResult(name string, begin time.Time, duration time.Duration, err error) {
+	lastScrapeResultsMtx.Lock()
+	defer lastScrapeResultsMtx.Unlock()
+	lastScrapeResults[name] = scrapeResult{time: begin, duration: duration, err: err}
+}
+
+// CollectorStatuses returns the status of all registered collectors, sorted
+// by name.
+func CollectorStatuses() []CollectorStatus {
+	lastScrapeResultsMtx.Lock()
+	defer lastScrapeResultsMtx.Unlock()
+
+	statuses := make([]CollectorStatus, 0, len(collectorState))
+	for name, enabled := range collectorState {
+		s := CollectorStatus{Name: name, Enabled: *enabled}
+		if r, ok := lastScrapeResults[name]; ok {
+			t, d := r.time, r.duration.Seconds()
+			// Like node_scrape_collector_success, no data is not a success.
+			success := r.err == nil
+			s.LastScrape, s.LastDurationSeconds, s.LastSuccess = &t, &d, &success
+			if r.err != nil {
+				s.LastError = r.err.Error()
+			}
+		}
+		statuses = append(statuses, s)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCollectorStatuses(t *testing.T) {
+	begin := time.Now()
+	recordScrapeResult("cpu", begin, time.Second, nil)
+	recordScrapeResult("meminfo", begin, 2*time.Second, errors.New("boom"))
+	defer func() {
+		lastScrapeResultsMtx.Lock()
+		delete(lastScrapeResults, "cpu")
+		delete(lastScrapeResults, "meminfo")
+		lastScrapeResultsMtx.Unlock()
+	}()
+
+	statuses := CollectorStatuses()
+	if len(statuses) != len(collectorState) {
+		t.Fatalf("got %d statuses, want %d", len(statuses), len(collectorState))
+	}
+	byName := map[string]CollectorStatus{}
+	for i, s := range statuses {
+		if i > 0 && statuses[i-1].Name >= s.Name {
+			t.Fatalf("statuses not sorted: %q before %q", statuses[i-1].Name, s.Name)
+		}
+		byName[s.Name] = s
+	}
+
+	cpu := byName["cpu"]
+	if cpu.LastSuccess == nil || !*cpu.LastSuccess || *cpu.LastDurationSeconds != 1 || !cpu.LastScrape.Equal(begin) || cpu.LastError != "" {
+		t.Errorf("unexpected cpu status: %+v", cpu)
+	}
+	meminfo := byName["meminfo"]
+	if meminfo.LastSuccess == nil || *meminfo.LastSuccess || *meminfo.LastDurationSeconds != 2 || meminfo.LastError != "boom" {
+		t.Errorf("unexpected meminfo status: %+v", meminfo)
+	}
+	if s := byName["loadavg"]; s.LastScrape != nil || s.LastSuccess != nil {
+		t.Errorf("expected no scrape result for loadavg, got %+v", s)
+	}
+}
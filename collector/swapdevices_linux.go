@@ -0,0 +1,82 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noswapdevices
+// +build !noswapdevices
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+)
+
+const swapDeviceSubsystem = "swap_device"
+
+type swapDevicesCollector struct {
+	fs     procfs.FS
+	size   typedDesc
+	used   typedDesc
+	logger *slog.Logger
+}
+
+func init() {
+	registerCollector("swapdevices", defaultDisabled, NewSwapDevicesCollector)
+}
+
+// NewSwapDevicesCollector returns a new Collector exposing the swap devices
+// listed in /proc/swaps.
+func NewSwapDevicesCollector(logger *slog.Logger) (Collector, error) {
+	fs, err := procfs.NewFS(*procPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open procfs: %w", err)
+	}
+
+	return &swapDevicesCollector{
+		fs: fs,
+		size: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, swapDeviceSubsystem, "size_bytes"),
+			"Size of the swap device or file in bytes.",
+			[]string{"device"}, nil,
+		), prometheus.GaugeValue},
+		used: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, swapDeviceSubsystem, "used_bytes"),
+			"Used space of the swap device or file in bytes.",
+			[]string{"device"}, nil,
+		), prometheus.GaugeValue},
+		logger: logger,
+	}, nil
+}
+
+func (c *swapDevicesCollector) Update(ch chan<- prometheus.Metric) error {
+	swaps, err := c.fs.Swaps()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			c.logger.Debug("Not collecting swap devices, /proc/swaps not found", "err", err)
+			return ErrNoData
+		}
+		return fmt.Errorf("couldn't get swap devices: %w", err)
+	}
+
+	// /proc/swaps reports sizes in KiB for partitions and files alike.
+	for _, swap := range swaps {
+		ch <- c.size.mustNewConstMetric(float64(swap.Size)*1024, swap.Filename)
+		ch <- c.used.mustNewConstMetric(float64(swap.Used)*1024, swap.Filename)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noswapdevices
+// +build !noswapdevices
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testSwapDevicesCollector struct {
+	sc Collector
+}
+
+func (c testSwapDevicesCollector) Collect(ch chan<- prometheus.Metric) {
+	c.sc.Update(ch)
+}
+
+func (c testSwapDevicesCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestSwapDevices(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := NewSwapDevicesCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_swap_device_size_bytes Size of the swap device or file in bytes.
+		# TYPE node_swap_device_size_bytes gauge
+		node_swap_device_size_bytes{device="/dev/dm-2"} 1.34213632e+08
+		node_swap_device_size_bytes{device="/swapfile"} 1.073737728e+09
+		# HELP node_swap_device_used_bytes Used space of the swap device or file in bytes.
+		# TYPE node_swap_device_used_bytes gauge
+		node_swap_device_used_bytes{device="/dev/dm-2"} 180224
+		node_swap_device_used_bytes{device="/swapfile"} 0
+`
+	if err := testutil.CollectAndCompare(testSwapDevicesCollector{sc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
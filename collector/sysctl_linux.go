@@ -16,6 +16,8 @@ package collector
 import (
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -67,6 +69,14 @@ func NewSysctlCollector(logger *slog.Logger) (Collector, error) {
 		}
 		c.sysctls = append(c.sysctls, sysctl)
 	}
+
+	// Catch typos in the configured sysctls at startup instead of failing
+	// every scrape.
+	for _, sysctl := range c.sysctls {
+		if _, err := os.Stat(procFilePath(filepath.Join("sys", strings.ReplaceAll(sysctl.name, ".", "/")))); err != nil {
+			return nil, fmt.Errorf("sysctl %s not found: %w", sysctl.name, err)
+		}
+	}
 	return c, nil
 }
 
@@ -0,0 +1,96 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testSysctlCollector struct {
+	sc Collector
+}
+
+func (c testSysctlCollector) Collect(ch chan<- prometheus.Metric) {
+	c.sc.Update(ch)
+}
+
+func (c testSysctlCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestSysctlCollector(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--path.procfs", "fixtures/proc",
+		"--collector.sysctl.include", "kernel.threads-max",
+		"--collector.sysctl.include", "fs.file-nr:total,current,max",
+		"--collector.sysctl.include-info", "kernel.seccomp.actions_avail",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *sysctlInclude, *sysctlIncludeInfo = nil, nil }()
+
+	sc, err := NewSysctlCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_sysctl_fs_file_nr_current sysctl fs.file-nr, field 1
+		# TYPE node_sysctl_fs_file_nr_current untyped
+		node_sysctl_fs_file_nr_current 0
+		# HELP node_sysctl_fs_file_nr_max sysctl fs.file-nr, field 2
+		# TYPE node_sysctl_fs_file_nr_max untyped
+		node_sysctl_fs_file_nr_max 1.631329e+06
+		# HELP node_sysctl_fs_file_nr_total sysctl fs.file-nr, field 0
+		# TYPE node_sysctl_fs_file_nr_total untyped
+		node_sysctl_fs_file_nr_total 1024
+		# HELP node_sysctl_info sysctl info
+		# TYPE node_sysctl_info gauge
+		node_sysctl_info{index="0",name="kernel.seccomp.actions_avail",value="kill_process"} 1
+		node_sysctl_info{index="1",name="kernel.seccomp.actions_avail",value="kill_thread"} 1
+		node_sysctl_info{index="2",name="kernel.seccomp.actions_avail",value="trap"} 1
+		node_sysctl_info{index="3",name="kernel.seccomp.actions_avail",value="errno"} 1
+		node_sysctl_info{index="4",name="kernel.seccomp.actions_avail",value="user_notif"} 1
+		node_sysctl_info{index="5",name="kernel.seccomp.actions_avail",value="trace"} 1
+		node_sysctl_info{index="6",name="kernel.seccomp.actions_avail",value="log"} 1
+		node_sysctl_info{index="7",name="kernel.seccomp.actions_avail",value="allow"} 1
+		# HELP node_sysctl_kernel_threads_max sysctl kernel.threads-max
+		# TYPE node_sysctl_kernel_threads_max untyped
+		node_sysctl_kernel_threads_max 7801
+`
+	if err := testutil.CollectAndCompare(testSysctlCollector{sc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSysctlCollectorMissing(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--path.procfs", "fixtures/proc",
+		"--collector.sysctl.include", "net.core.somaxconn",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *sysctlInclude = nil }()
+
+	_, err := NewSysctlCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err == nil || !strings.Contains(err.Error(), "sysctl net.core.somaxconn not found") {
+		t.Fatalf("want error for missing sysctl, got %v", err)
+	}
+}
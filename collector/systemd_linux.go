@@ -52,11 +52,13 @@ var (
 		systemdUnitExcludeSet = true
 		return nil
 	}).String()
-	oldSystemdUnitExclude  = kingpin.Flag("collector.systemd.unit-blacklist", "DEPRECATED: Use collector.systemd.unit-exclude").Hidden().String()
-	systemdPrivate         = kingpin.Flag("collector.systemd.private", "Establish a private, direct connection to systemd without dbus (Strongly discouraged since it requires root. For testing purposes only).").Hidden().Bool()
-	enableTaskMetrics      = kingpin.Flag("collector.systemd.enable-task-metrics", "Enables service unit tasks metrics unit_tasks_current and unit_tasks_max").Bool()
-	enableRestartsMetrics  = kingpin.Flag("collector.systemd.enable-restarts-metrics", "Enables service unit metric service_restart_total").Bool()
-	enableStartTimeMetrics = kingpin.Flag("collector.systemd.enable-start-time-metrics", "Enables service unit metric unit_start_time_seconds").Bool()
+	systemdUnitIncludeGlobs = kingpin.Flag("collector.systemd.unit-include-pattern", "Shell glob of systemd units to include, e.g. *.service. Units must match one of these globs in addition to --collector.systemd.unit-include and --collector.systemd.unit-exclude. (repeatable)").Strings()
+	oldSystemdUnitExclude   = kingpin.Flag("collector.systemd.unit-blacklist", "DEPRECATED: Use collector.systemd.unit-exclude").Hidden().String()
+	systemdPrivate          = kingpin.Flag("collector.systemd.private", "Establish a private, direct connection to systemd without dbus (Strongly discouraged since it requires root. For testing purposes only).").Hidden().Bool()
+	enableTaskMetrics       = kingpin.Flag("collector.systemd.enable-task-metrics", "Enables service unit tasks metrics unit_tasks_current and unit_tasks_max").Bool()
+	enableResourceMetrics   = kingpin.Flag("collector.systemd.enable-resource-metrics", "Enables service unit accounting metrics unit_memory_bytes and unit_cpu_seconds_total (task counts are covered by --collector.systemd.enable-task-metrics)").Bool()
+	enableRestartsMetrics   = kingpin.Flag("collector.systemd.enable-restarts-metrics", "Enables service unit metric service_restart_total").Bool()
+	enableStartTimeMetrics  = kingpin.Flag("collector.systemd.enable-start-time-metrics", "Enables service unit metric unit_start_time_seconds").Bool()
 
 	systemdVersionRE = regexp.MustCompile(`[0-9]{3,}(\.[0-9]+)?`)
 )
@@ -66,8 +68,11 @@ type systemdCollector struct {
 	unitStartTimeDesc             *prometheus.Desc
 	unitTasksCurrentDesc          *prometheus.Desc
 	unitTasksMaxDesc              *prometheus.Desc
+	unitMemoryDesc                *prometheus.Desc
+	unitCPUSecondsDesc            *prometheus.Desc
 	systemRunningDesc             *prometheus.Desc
 	summaryDesc                   *prometheus.Desc
+	nFailedUnitsDesc              *prometheus.Desc
 	nRestartsDesc                 *prometheus.Desc
 	timerLastTriggerDesc          *prometheus.Desc
 	socketAcceptedConnectionsDesc *prometheus.Desc
@@ -78,6 +83,7 @@ type systemdCollector struct {
 	// Use regexps for more flexibility than device_filter.go allows
 	systemdUnitIncludePattern *regexp.Regexp
 	systemdUnitExcludePattern *regexp.Regexp
+	systemdUnitGlobPattern    *regexp.Regexp
 	logger                    *slog.Logger
 }
 
@@ -107,6 +113,14 @@ func NewSystemdCollector(logger *slog.Logger) (Collector, error) {
 		prometheus.BuildFQName(namespace, subsystem, "unit_tasks_max"),
 		"Maximum number of tasks per Systemd unit", []string{"name"}, nil,
 	)
+	unitMemoryDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "unit_memory_bytes"),
+		"Current memory usage of the Systemd unit's cgroup in bytes", []string{"name"}, nil,
+	)
+	unitCPUSecondsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "unit_cpu_seconds_total"),
+		"Total CPU time consumed by the Systemd unit's cgroup in seconds", []string{"name"}, nil,
+	)
 	systemRunningDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, subsystem, "system_running"),
 		"Whether the system is operational (see 'systemctl is-system-running')",
@@ -115,6 +129,9 @@ func NewSystemdCollector(logger *slog.Logger) (Collector, error) {
 	summaryDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, subsystem, "units"),
 		"Summary of systemd unit states", []string{"state"}, nil)
+	nFailedUnitsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "nfailed_units"),
+		"Number of systemd units in failed state", nil, nil)
 	nRestartsDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, subsystem, "service_restart_total"),
 		"Service unit count of Restart triggers", []string{"name"}, nil)
@@ -157,14 +174,21 @@ func NewSystemdCollector(logger *slog.Logger) (Collector, error) {
 	systemdUnitIncludePattern := regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *systemdUnitInclude))
 	logger.Info("Parsed flag --collector.systemd.unit-exclude", "flag", *systemdUnitExclude)
 	systemdUnitExcludePattern := regexp.MustCompile(fmt.Sprintf("^(?:%s)$", *systemdUnitExclude))
+	systemdUnitGlobPattern, err := globsToRegexp(*systemdUnitIncludeGlobs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --collector.systemd.unit-include-pattern: %w", err)
+	}
 
 	return &systemdCollector{
 		unitDesc:                      unitDesc,
 		unitStartTimeDesc:             unitStartTimeDesc,
 		unitTasksCurrentDesc:          unitTasksCurrentDesc,
 		unitTasksMaxDesc:              unitTasksMaxDesc,
+		unitMemoryDesc:                unitMemoryDesc,
+		unitCPUSecondsDesc:            unitCPUSecondsDesc,
 		systemRunningDesc:             systemRunningDesc,
 		summaryDesc:                   summaryDesc,
+		nFailedUnitsDesc:              nFailedUnitsDesc,
 		nRestartsDesc:                 nRestartsDesc,
 		timerLastTriggerDesc:          timerLastTriggerDesc,
 		socketAcceptedConnectionsDesc: socketAcceptedConnectionsDesc,
@@ -174,6 +198,7 @@ func NewSystemdCollector(logger *slog.Logger) (Collector, error) {
 		virtualizationDesc:            virtualizationDesc,
 		systemdUnitIncludePattern:     systemdUnitIncludePattern,
 		systemdUnitExcludePattern:     systemdUnitExcludePattern,
+		systemdUnitGlobPattern:        systemdUnitGlobPattern,
 		logger:                        logger,
 	}, nil
 }
@@ -219,7 +244,7 @@ func (c *systemdCollector) Update(ch chan<- prometheus.Metric) error {
 	c.logger.Debug("collectSummaryMetrics took", "duration_seconds", time.Since(begin).Seconds())
 
 	begin = time.Now()
-	units := filterUnits(allUnits, c.systemdUnitIncludePattern, c.systemdUnitExcludePattern, c.logger)
+	units := filterUnits(allUnits, c.systemdUnitIncludePattern, c.systemdUnitExcludePattern, c.systemdUnitGlobPattern, c.logger)
 	c.logger.Debug("filterUnits took", "duration_seconds", time.Since(begin).Seconds())
 
 	var wg sync.WaitGroup
@@ -253,6 +278,16 @@ func (c *systemdCollector) Update(ch chan<- prometheus.Metric) error {
 		}()
 	}
 
+	if *enableResourceMetrics {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			begin = time.Now()
+			c.collectUnitResourceMetrics(conn, ch, units)
+			c.logger.Debug("collectUnitResourceMetrics took", "duration_seconds", time.Since(begin).Seconds())
+		}()
+	}
+
 	if systemdVersion >= minSystemdVersionSystemState {
 		wg.Add(1)
 		go func() {
@@ -408,6 +443,48 @@ func (c *systemdCollector) collectUnitTasksMetrics(conn *dbus.Conn, ch chan<- pr
 	}
 }
 
+// unitPropertyGetter is the part of *dbus.Conn used to read the accounting
+// properties of units.
+type unitPropertyGetter interface {
+	GetUnitTypePropertyContext(ctx context.Context, unit string, unitType string, propertyName string) (*dbus.Property, error)
+}
+
+func (c *systemdCollector) collectUnitResourceMetrics(conn unitPropertyGetter, ch chan<- prometheus.Metric, units []unit) {
+	for _, unit := range units {
+		if !strings.HasSuffix(unit.Name, ".service") {
+			continue
+		}
+		memoryCurrent, err := conn.GetUnitTypePropertyContext(context.TODO(), unit.Name, "Service", "MemoryCurrent")
+		if err != nil {
+			c.logger.Debug("couldn't get unit MemoryCurrent", "unit", unit.Name, "err", err)
+		} else {
+			val, ok := memoryCurrent.Value.Value().(uint64)
+			if !ok {
+				c.logger.Debug("unexpected type of unit MemoryCurrent", "unit", unit.Name, "type", memoryCurrent.Value.Signature())
+			} else if val != math.MaxUint64 {
+				// Don't set if memory accounting is disabled and dbus reports MaxUint64.
+				ch <- prometheus.MustNewConstMetric(
+					c.unitMemoryDesc, prometheus.GaugeValue,
+					float64(val), unit.Name)
+			}
+		}
+		cpuUsage, err := conn.GetUnitTypePropertyContext(context.TODO(), unit.Name, "Service", "CPUUsageNSec")
+		if err != nil {
+			c.logger.Debug("couldn't get unit CPUUsageNSec", "unit", unit.Name, "err", err)
+		} else {
+			val, ok := cpuUsage.Value.Value().(uint64)
+			if !ok {
+				c.logger.Debug("unexpected type of unit CPUUsageNSec", "unit", unit.Name, "type", cpuUsage.Value.Signature())
+			} else if val != math.MaxUint64 {
+				// Don't set if CPU accounting is disabled and dbus reports MaxUint64.
+				ch <- prometheus.MustNewConstMetric(
+					c.unitCPUSecondsDesc, prometheus.CounterValue,
+					float64(val)/1e9, unit.Name)
+			}
+		}
+	}
+}
+
 func (c *systemdCollector) collectTimers(conn *dbus.Conn, ch chan<- prometheus.Metric, units []unit) {
 	for _, unit := range units {
 		if !strings.HasSuffix(unit.Name, ".timer") {
@@ -431,6 +508,10 @@ func (c *systemdCollector) collectSummaryMetrics(ch chan<- prometheus.Metric, su
 		ch <- prometheus.MustNewConstMetric(
 			c.summaryDesc, prometheus.GaugeValue, count, stateName)
 	}
+	// Same count as systemd's NFailedUnits manager property, without
+	// another dbus round trip.
+	ch <- prometheus.MustNewConstMetric(
+		c.nFailedUnitsDesc, prometheus.GaugeValue, summary["failed"])
 }
 
 func (c *systemdCollector) collectSystemState(conn *dbus.Conn, ch chan<- prometheus.Metric) error {
@@ -488,10 +569,13 @@ func summarizeUnits(units []unit) map[string]float64 {
 	return summarized
 }
 
-func filterUnits(units []unit, includePattern, excludePattern *regexp.Regexp, logger *slog.Logger) []unit {
+// filterUnits returns the loaded units matching includePattern and not
+// excludePattern. If globPattern isn't nil, units must match it as well.
+func filterUnits(units []unit, includePattern, excludePattern, globPattern *regexp.Regexp, logger *slog.Logger) []unit {
 	filtered := make([]unit, 0, len(units))
 	for _, unit := range units {
-		if includePattern.MatchString(unit.Name) && !excludePattern.MatchString(unit.Name) && unit.LoadState == "loaded" {
+		if includePattern.MatchString(unit.Name) && !excludePattern.MatchString(unit.Name) &&
+			(globPattern == nil || globPattern.MatchString(unit.Name)) && unit.LoadState == "loaded" {
 			logger.Debug("Adding unit", "unit", unit.Name)
 			filtered = append(filtered, unit)
 		} else {
@@ -502,6 +586,60 @@ func filterUnits(units []unit, includePattern, excludePattern *regexp.Regexp, lo
 	return filtered
 }
 
+// globsToRegexp translates shell globs into an anchored regexp matching any
+// of them, or returns nil if there are none. Besides * and ?, globs may
+// contain character classes like [0-9], negated with [!a] or [^a]. Unlike
+// with path.Match, \ is a literal character, as in systemd's escapes like
+// \x2d.
+func globsToRegexp(globs []string) (*regexp.Regexp, error) {
+	if len(globs) == 0 {
+		return nil, nil
+	}
+	alternatives := make([]string, 0, len(globs))
+	for _, glob := range globs {
+		var b strings.Builder
+		runes := []rune(glob)
+		for i := 0; i < len(runes); i++ {
+			switch r := runes[i]; r {
+			case '*':
+				b.WriteString("[^/]*")
+			case '?':
+				b.WriteString("[^/]")
+			case '[':
+				j := i + 1
+				negated := j < len(runes) && (runes[j] == '!' || runes[j] == '^')
+				if negated {
+					j++
+				}
+				// A ] right after the opening bracket is part of the class.
+				start := j
+				for j < len(runes) && (runes[j] != ']' || j == start) {
+					j++
+				}
+				if j >= len(runes) {
+					return nil, fmt.Errorf("unterminated character class in %q", glob)
+				}
+				b.WriteString("[")
+				if negated {
+					b.WriteString("^")
+				}
+				for _, c := range runes[start:j] {
+					if c == '\\' || c == '[' || c == ']' {
+						b.WriteRune('\\')
+					}
+					b.WriteRune(c)
+				}
+				b.WriteString("]")
+				i = j
+			default:
+				b.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		alternatives = append(alternatives, b.String())
+	}
+	return regexp.Compile("^(?:" + strings.Join(alternatives, "|") + ")$")
+}
+
 func (c *systemdCollector) getSystemdVersion(conn *dbus.Conn) (float64, string) {
 	version, err := conn.GetManagerProperty("Version")
 	if err != nil {
@@ -17,12 +17,21 @@
 package collector
 
 import (
+	"context"
+	"errors"
 	"io"
 	"log/slog"
+	"math"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/alecthomas/kingpin/v2"
 	"github.com/coreos/go-systemd/v22/dbus"
+	godbus "github.com/godbus/dbus/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 // Creates mock UnitLists
@@ -95,7 +104,7 @@ func TestSystemdIgnoreFilter(t *testing.T) {
 	fixtures := getUnitListFixtures()
 	includePattern := regexp.MustCompile("^foo$")
 	excludePattern := regexp.MustCompile("^bar$")
-	filtered := filterUnits(fixtures[0], includePattern, excludePattern, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	filtered := filterUnits(fixtures[0], includePattern, excludePattern, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	for _, unit := range filtered {
 		if excludePattern.MatchString(unit.Name) || !includePattern.MatchString(unit.Name) {
 			t.Error(unit.Name, "should not be in the filtered list")
@@ -103,6 +112,10 @@ func TestSystemdIgnoreFilter(t *testing.T) {
 	}
 }
 func TestSystemdIgnoreFilterDefaultKeepsAll(t *testing.T) {
+	// Set the flags to their defaults.
+	if _, err := kingpin.CommandLine.Parse([]string{}); err != nil {
+		t.Fatal(err)
+	}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	c, err := NewSystemdCollector(logger)
 	if err != nil {
@@ -110,13 +123,55 @@ func TestSystemdIgnoreFilterDefaultKeepsAll(t *testing.T) {
 	}
 	fixtures := getUnitListFixtures()
 	collector := c.(*systemdCollector)
-	filtered := filterUnits(fixtures[0], collector.systemdUnitIncludePattern, collector.systemdUnitExcludePattern, logger)
+	filtered := filterUnits(fixtures[0], collector.systemdUnitIncludePattern, collector.systemdUnitExcludePattern, collector.systemdUnitGlobPattern, logger)
 	// Adjust fixtures by 3 "not-found" units.
 	if len(filtered) != len(fixtures[0])-3 {
 		t.Error("Default filters removed units")
 	}
 }
 
+func TestSystemdGlobFilter(t *testing.T) {
+	units := []unit{}
+	for _, name := range []string{"ssh.service", "cron.service", "getty@tty1.service", "user@1000.service", "logrotate.timer", "]x-ü.service", `dev-disk-by\x2duuid-1234.device`} {
+		units = append(units, unit{UnitStatus: dbus.UnitStatus{Name: name, LoadState: "loaded"}})
+	}
+	for _, test := range []struct {
+		globs   []string
+		include string
+		want    []string
+	}{
+		{globs: []string{"*.service"}, include: ".+", want: []string{"ssh.service", "cron.service", "getty@tty1.service", "user@1000.service", "]x-ü.service"}},
+		{globs: []string{"getty@tty?.service", "*.timer"}, include: ".+", want: []string{"getty@tty1.service", "logrotate.timer"}},
+		{globs: []string{"user@[0-9]*.service"}, include: ".+", want: []string{"user@1000.service"}},
+		{globs: []string{"[^c]*.service"}, include: ".+", want: []string{"ssh.service", "getty@tty1.service", "user@1000.service", "]x-ü.service"}},
+		{globs: []string{"[!c]*.service"}, include: ".+", want: []string{"ssh.service", "getty@tty1.service", "user@1000.service", "]x-ü.service"}},
+		{globs: []string{"[]x]*.service"}, include: ".+", want: []string{"]x-ü.service"}},
+		// systemd escapes characters in unit names with \.
+		{globs: []string{`dev-disk-by\x2duuid-*.device`}, include: ".+", want: []string{`dev-disk-by\x2duuid-1234.device`}},
+		{globs: []string{"?x-ü.service"}, include: ".+", want: []string{"]x-ü.service"}},
+		// Units must match both the globs and the include regexp.
+		{globs: []string{"*.service"}, include: "(ssh|logrotate)\\..+", want: []string{"ssh.service"}},
+	} {
+		globPattern, err := globsToRegexp(test.globs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		includePattern := regexp.MustCompile("^(?:" + test.include + ")$")
+		filtered := filterUnits(units, includePattern, regexp.MustCompile("^$"), globPattern, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		got := []string{}
+		for _, unit := range filtered {
+			got = append(got, unit.Name)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("globs %q, include %q: want units %q, got %q", test.globs, test.include, test.want, got)
+		}
+	}
+
+	if _, err := globsToRegexp([]string{"*.service", "foo[.service"}); err == nil {
+		t.Error("want error for unterminated character class")
+	}
+}
+
 func TestSystemdSummary(t *testing.T) {
 	fixtures := getUnitListFixtures()
 	summary := summarizeUnits(fixtures[0])
@@ -133,8 +188,112 @@ func TestSystemdSummary(t *testing.T) {
 	}
 }
 
+type testSystemdSummaryCollector struct {
+	c     *systemdCollector
+	units []unit
+}
+
+func (c testSystemdSummaryCollector) Collect(ch chan<- prometheus.Metric) {
+	c.c.collectSummaryMetrics(ch, summarizeUnits(c.units))
+}
+
+func (c testSystemdSummaryCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestSystemdSummaryMetrics(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewSystemdCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	units := append(getUnitListFixtures()[0],
+		unit{UnitStatus: dbus.UnitStatus{Name: "broken.service", LoadState: "loaded", ActiveState: "failed"}},
+		unit{UnitStatus: dbus.UnitStatus{Name: "gone.service", LoadState: "not-found", ActiveState: "failed"}},
+	)
+
+	want := `# HELP node_systemd_nfailed_units Number of systemd units in failed state
+		# TYPE node_systemd_nfailed_units gauge
+		node_systemd_nfailed_units 2
+		# HELP node_systemd_units Summary of systemd unit states
+		# TYPE node_systemd_units gauge
+		node_systemd_units{state="activating"} 0
+		node_systemd_units{state="active"} 1
+		node_systemd_units{state="deactivating"} 0
+		node_systemd_units{state="failed"} 2
+		node_systemd_units{state="inactive"} 3
+`
+	if err := testutil.CollectAndCompare(testSystemdSummaryCollector{c.(*systemdCollector), units}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func testSummaryHelper(t *testing.T, state string, actual float64, expected float64) {
 	if actual != expected {
 		t.Errorf("Summary mode didn't count %s jobs correctly. Actual: %f, expected: %f", state, actual, expected)
 	}
 }
+
+// fakeUnitProperties returns the properties of units keyed by unit and
+// property name.
+type fakeUnitProperties map[string]map[string]interface{}
+
+func (f fakeUnitProperties) GetUnitTypePropertyContext(_ context.Context, unit string, _ string, propertyName string) (*dbus.Property, error) {
+	value, ok := f[unit][propertyName]
+	if !ok {
+		return nil, errors.New("no such property")
+	}
+	return &dbus.Property{Name: propertyName, Value: godbus.MakeVariant(value)}, nil
+}
+
+type testSystemdResourceCollector struct {
+	c     *systemdCollector
+	conn  unitPropertyGetter
+	units []unit
+}
+
+func (c testSystemdResourceCollector) Collect(ch chan<- prometheus.Metric) {
+	c.c.collectUnitResourceMetrics(c.conn, ch, c.units)
+}
+
+func (c testSystemdResourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestSystemdUnitResourceMetrics(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewSystemdCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := fakeUnitProperties{
+		"foo.service": {"MemoryCurrent": uint64(1024), "CPUUsageNSec": uint64(1500000000)},
+		// Accounting is disabled.
+		"noaccounting.service": {"MemoryCurrent": uint64(math.MaxUint64), "CPUUsageNSec": uint64(math.MaxUint64)},
+		// Only the metrics of properties with unexpected types are skipped.
+		"odd.service":   {"MemoryCurrent": "1024", "CPUUsageNSec": uint64(1000000000)},
+		"nocpu.service": {"MemoryCurrent": uint64(2048)},
+		"foo.socket":    {"MemoryCurrent": uint64(1024)},
+	}
+	var units []unit
+	for _, name := range []string{"foo.service", "noaccounting.service", "odd.service", "nocpu.service", "foo.socket"} {
+		units = append(units, unit{UnitStatus: dbus.UnitStatus{Name: name}})
+	}
+
+	want := `# HELP node_systemd_unit_cpu_seconds_total Total CPU time consumed by the Systemd unit's cgroup in seconds
+		# TYPE node_systemd_unit_cpu_seconds_total counter
+		node_systemd_unit_cpu_seconds_total{name="foo.service"} 1.5
+		node_systemd_unit_cpu_seconds_total{name="odd.service"} 1
+		# HELP node_systemd_unit_memory_bytes Current memory usage of the Systemd unit's cgroup in bytes
+		# TYPE node_systemd_unit_memory_bytes gauge
+		node_systemd_unit_memory_bytes{name="foo.service"} 1024
+		node_systemd_unit_memory_bytes{name="nocpu.service"} 2048
+`
+	if err := testutil.CollectAndCompare(testSystemdResourceCollector{c.(*systemdCollector), conn, units}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -17,21 +17,41 @@
 package collector
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs"
 )
 
 const userHZ = 100
 
+var (
+	systemdStatsSubsystem = kingpin.Flag("collector.systemdstats.subsystem", "Subsystem of the systemdstats metric names, which keep the node namespace.").Default("systemdstats").String()
+
+	// metricNameComponentRE matches the namespaces and subsystems allowed
+	// in metric names. Colons are reserved for recording rules.
+	metricNameComponentRE = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
 type systemdStatsCollector struct {
 	Name         string
 	Pid          int
 	fs           procfs.FS
 	cpuSecDesc   *prometheus.Desc
 	membytesDesc *prometheus.Desc
+	cpusDesc     *prometheus.Desc
+	memsDesc     *prometheus.Desc
+	cgroupDesc   *prometheus.Desc
 	logger       *slog.Logger
 }
 
@@ -45,7 +65,10 @@ func NewSystemdStatsCollector(logger *slog.Logger) (Collector, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to open procfs: %w", err)
 	}
-	subsystem := "systemdstats"
+	subsystem := *systemdStatsSubsystem
+	if !metricNameComponentRE.MatchString(subsystem) {
+		return nil, fmt.Errorf("invalid --collector.systemdstats.subsystem %q: must match %s", subsystem, metricNameComponentRE)
+	}
 	return &systemdStatsCollector{
 		Name: "systemd",
 		Pid:  1,
@@ -62,6 +85,24 @@ func NewSystemdStatsCollector(logger *slog.Logger) (Collector, error) {
 			nil,
 			nil,
 		),
+		cpusDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "cpus_allowed"),
+			"Number of CPUs the process is allowed to run on, from Cpus_allowed_list.",
+			nil,
+			nil,
+		),
+		memsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "mems_allowed"),
+			"Number of NUMA nodes the process is allowed to allocate memory on, from Mems_allowed_list.",
+			nil,
+			nil,
+		),
+		cgroupDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "cgroup_info"),
+			"The cgroup of the process, from /proc/[pid]/cgroup.",
+			[]string{"pid", "cgroup"},
+			nil,
+		),
 		logger: logger,
 	}, nil
 }
@@ -70,22 +111,108 @@ func NewSystemdStatsCollector(logger *slog.Logger) (Collector, error) {
 func (c *systemdStatsCollector) Update(ch chan<- prometheus.Metric) error {
 
 	// read from /proc/[pid]/stat
-	p, err := procfs.NewProc(c.Pid)
+	p, err := c.fs.Proc(c.Pid)
 	if err != nil {
-		return err
+		return c.procError(err)
 	}
 
 	stat, err := p.Stat()
+	if err != nil {
+		return c.procError(err)
+	}
+
+	// 进程的启动时间，作为cpu计数器的创建时间(OpenMetrics的_created)
+	startTime, err := stat.StartTime()
 	if err != nil {
 		return err
 	}
+	created := time.Unix(0, int64(startTime*float64(time.Second)))
 
 	// 进程的cpu使用量(seconds):分为用户和系统时间，字段utime和stime。原始数据单位是jiffies，转换为seconds需要除以userHZ
-	ch <- prometheus.MustNewConstMetric(c.cpuSecDesc, prometheus.CounterValue, float64(stat.UTime)/userHZ, "user")
-	ch <- prometheus.MustNewConstMetric(c.cpuSecDesc, prometheus.CounterValue, float64(stat.STime)/userHZ, "system")
+	ch <- prometheus.MustNewConstMetricWithCreatedTimestamp(c.cpuSecDesc, prometheus.CounterValue, float64(stat.UTime)/userHZ, created, "user")
+	ch <- prometheus.MustNewConstMetricWithCreatedTimestamp(c.cpuSecDesc, prometheus.CounterValue, float64(stat.STime)/userHZ, created, "system")
 
 	// 进程的内存使用量(bytes):驻留内存RES
 	ch <- prometheus.MustNewConstMetric(c.membytesDesc, prometheus.GaugeValue, float64(stat.ResidentMemory()))
 
+	// 进程允许使用的cpu和NUMA节点数量:/proc/[pid]/status中的Cpus_allowed_list和Mems_allowed_list
+	// The cpu and memory metrics are already sent, so later errors only
+	// skip the metrics they affect.
+	allowed, err := readAllowedLists(procFilePath(filepath.Join(strconv.Itoa(c.Pid), "status")))
+	if err != nil {
+		c.logger.Debug("couldn't read allowed CPUs and NUMA nodes", "pid", c.Pid, "err", err)
+	}
+	if cpus, ok := allowed["Cpus_allowed_list"]; ok {
+		ch <- prometheus.MustNewConstMetric(c.cpusDesc, prometheus.GaugeValue, float64(cpus))
+	}
+	if mems, ok := allowed["Mems_allowed_list"]; ok {
+		ch <- prometheus.MustNewConstMetric(c.memsDesc, prometheus.GaugeValue, float64(mems))
+	}
+
+	// 进程所属的cgroup:/proc/[pid]/cgroup,用于关联进程和cgroup的指标
+	cgroups, err := p.Cgroups()
+	if err != nil {
+		c.logger.Debug("couldn't read cgroups", "pid", c.Pid, "err", err)
+	} else if cgroup, ok := systemdCgroup(cgroups); ok {
+		ch <- prometheus.MustNewConstMetric(c.cgroupDesc, prometheus.GaugeValue, 1, strconv.Itoa(c.Pid), cgroup)
+	}
+
 	return nil
 }
+
+// systemdCgroup returns the cgroup path systemd tracks the process in: the
+// name=systemd hierarchy with cgroup v1 and the hybrid layout, the unified
+// hierarchy otherwise.
+func systemdCgroup(cgroups []procfs.Cgroup) (string, bool) {
+	var unified *procfs.Cgroup
+	for i, cgroup := range cgroups {
+		for _, controller := range cgroup.Controllers {
+			if controller == "name=systemd" {
+				return cgroup.Path, true
+			}
+		}
+		if cgroup.HierarchyID == 0 {
+			unified = &cgroups[i]
+		}
+	}
+	if unified == nil {
+		return "", false
+	}
+	return unified.Path, true
+}
+
+// readAllowedLists returns the parsed Cpus_allowed_list and
+// Mems_allowed_list of a /proc/[pid]/status file. Kernels without cpusets
+// may lack the fields.
+func readAllowedLists(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	allowed := map[string]uint64{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || (key != "Cpus_allowed_list" && key != "Mems_allowed_list") {
+			continue
+		}
+		count, err := countRangeList(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		allowed[key] = count
+	}
+	return allowed, scanner.Err()
+}
+
+// procError turns errors for a process that is gone or hidden from us, e.g.
+// in a locked-down container, into ErrNoData so they don't fail the scrape.
+func (c *systemdStatsCollector) procError(err error) error {
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+		c.logger.Debug("couldn't read process stats", "pid", c.Pid, "err", err)
+		return ErrNoData
+	}
+	return fmt.Errorf("couldn't read stats of process %d: %w", c.Pid, err)
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nosystemdstats
+// +build !nosystemdstats
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/procfs"
+)
+
+type testSystemdStatsCollector struct {
+	sc Collector
+}
+
+func (c testSystemdStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.sc.Update(ch)
+}
+
+func (c testSystemdStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestSystemdStatsCollector(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := NewSystemdStatsCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := fmt.Sprintf(`# HELP node_systemdstats_cgroup_info The cgroup of the process, from /proc/[pid]/cgroup.
+		# TYPE node_systemdstats_cgroup_info gauge
+		node_systemdstats_cgroup_info{cgroup="/init.scope",pid="1"} 1
+		# HELP node_systemdstats_cpu_seconds_total Cpu usage in seconds
+		# TYPE node_systemdstats_cpu_seconds_total counter
+		node_systemdstats_cpu_seconds_total{mode="system"} 0.98
+		node_systemdstats_cpu_seconds_total{mode="user"} 0.36
+		# HELP node_systemdstats_cpus_allowed Number of CPUs the process is allowed to run on, from Cpus_allowed_list.
+		# TYPE node_systemdstats_cpus_allowed gauge
+		node_systemdstats_cpus_allowed 12
+		# HELP node_systemdstats_memory_Resident_bytes number of bytes of memory in use
+		# TYPE node_systemdstats_memory_Resident_bytes gauge
+		node_systemdstats_memory_Resident_bytes %d
+		# HELP node_systemdstats_mems_allowed Number of NUMA nodes the process is allowed to allocate memory on, from Mems_allowed_list.
+		# TYPE node_systemdstats_mems_allowed gauge
+		node_systemdstats_mems_allowed 2
+`, 2507*os.Getpagesize())
+	if err := testutil.CollectAndCompare(testSystemdStatsCollector{sc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSystemdStatsCollectorNoProcess(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := NewSystemdStatsCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc.(*systemdStatsCollector).Pid = 999999
+
+	if err := sc.Update(make(chan prometheus.Metric, 3)); !errors.Is(err, ErrNoData) {
+		t.Fatalf("want ErrNoData, got %v", err)
+	}
+}
+
+func TestSystemdStatsCollectorPartialProcess(t *testing.T) {
+	// Without the status and cgroup files of the process, the metrics read
+	// before are still exposed.
+	proc := t.TempDir()
+	for _, file := range []string{"stat", "1/stat"} {
+		data, err := os.ReadFile(filepath.Join("fixtures/proc", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Join(proc, filepath.Dir(file)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(proc, file), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", proc}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *procPath = "fixtures/proc" }()
+	sc, err := NewSystemdStatsCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := make(chan prometheus.Metric, 10)
+	if err := sc.Update(ch); err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+	if len(ch) != 3 {
+		t.Errorf("want the cpu and memory metrics, got %d metrics", len(ch))
+	}
+}
+
+func TestSystemdStatsSubsystem(t *testing.T) {
+	for _, test := range []struct {
+		subsystem string
+		want      string
+		wantErr   bool
+	}{
+		{subsystem: "systemdstats", want: `"node_systemdstats_cpu_seconds_total"`},
+		{subsystem: "pid1", want: `"node_pid1_cpu_seconds_total"`},
+		{subsystem: "", wantErr: true},
+		{subsystem: "pid-1", wantErr: true},
+		{subsystem: "1pid", wantErr: true},
+	} {
+		t.Run(test.subsystem, func(t *testing.T) {
+			if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc", "--collector.systemdstats.subsystem", test.subsystem}); err != nil {
+				t.Fatal(err)
+			}
+			c, err := NewSystemdStatsCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("want error for invalid subsystem")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if desc := c.(*systemdStatsCollector).cpuSecDesc.String(); !strings.Contains(desc, test.want) {
+				t.Errorf("want metric name %s, got %s", test.want, desc)
+			}
+		})
+	}
+}
+
+func TestSystemdCgroup(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		cgroup string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "unified",
+			cgroup: "0::/init.scope\n",
+			want:   "/init.scope",
+			wantOK: true,
+		},
+		{
+			name:   "legacy",
+			cgroup: "12:pids:/init.scope\n3:cpu,cpuacct:/\n1:name=systemd:/init.scope\n",
+			want:   "/init.scope",
+			wantOK: true,
+		},
+		{
+			// The unified hierarchy only tracks processes here, the
+			// name=systemd one is what systemd uses.
+			name:   "hybrid",
+			cgroup: "12:pids:/init.scope\n1:name=systemd:/init.scope\n0::/\n",
+			want:   "/init.scope",
+			wantOK: true,
+		},
+		{
+			name:   "other hierarchies only",
+			cgroup: "3:cpu,cpuacct:/\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			procPath := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(procPath, "1"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(procPath, "1/cgroup"), []byte(test.cgroup), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			fs, err := procfs.NewFS(procPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := fs.Proc(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cgroups, err := p.Cgroups()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := systemdCgroup(cgroups)
+			if got != test.want || ok != test.wantOK {
+				t.Errorf("want %q, %t, got %q, %t", test.want, test.wantOK, got, ok)
+			}
+		})
+	}
+}
@@ -17,14 +17,17 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"syscall"
 	"unsafe"
 
-	"github.com/mdlayher/netlink"
+	"github.com/josharian/native"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+	"golang.org/x/sys/unix"
 )
 
 type tcpConnectionState int
@@ -59,6 +62,7 @@ const (
 )
 
 type tcpStatCollector struct {
+	fs     procfs.FS
 	desc   typedDesc
 	logger *slog.Logger
 }
@@ -69,7 +73,12 @@ func init() {
 
 // NewTCPStatCollector returns a new Collector exposing network stats.
 func NewTCPStatCollector(logger *slog.Logger) (Collector, error) {
+	fs, err := procfs.NewFS(*procPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open procfs: %w", err)
+	}
 	return &tcpStatCollector{
+		fs: fs,
 		desc: typedDesc{prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "tcp", "connection_states"),
 			"Number of connection states.",
@@ -129,14 +138,14 @@ func parseInetDiagMsg(b []byte) *InetDiagMsg {
 }
 
 func (c *tcpStatCollector) Update(ch chan<- prometheus.Metric) error {
-	tcpStats, err := getTCPStats(syscall.AF_INET)
+	tcpStats, err := c.getTCPStats(syscall.AF_INET)
 	if err != nil {
 		return fmt.Errorf("couldn't get tcpstats: %w", err)
 	}
 
 	// if enabled ipv6 system
 	if _, hasIPv6 := os.Stat(procFilePath("net/tcp6")); hasIPv6 == nil {
-		tcp6Stats, err := getTCPStats(syscall.AF_INET6)
+		tcp6Stats, err := c.getTCPStats(syscall.AF_INET6)
 		if err != nil {
 			return fmt.Errorf("couldn't get tcp6stats: %w", err)
 		}
@@ -153,50 +162,131 @@ func (c *tcpStatCollector) Update(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
-func getTCPStats(family uint8) (map[tcpConnectionState]float64, error) {
+// getTCPStats counts the TCP sockets of family with inet_diag, falling back
+// to /proc/net/tcp{,6} on kernels without it, e.g. when the inet_diag module
+// isn't loaded.
+func (c *tcpStatCollector) getTCPStats(family uint8) (map[tcpConnectionState]float64, error) {
+	tcpStats, err := getInetDiagTCPStats(family)
+	if err == nil {
+		return tcpStats, nil
+	}
+	c.logger.Debug("couldn't get tcpstats from netlink, falling back to procfs", "family", family, "err", err)
+
+	var sockets procfs.NetTCP
+	if family == syscall.AF_INET6 {
+		sockets, err = c.fs.NetTCP6()
+	} else {
+		sockets, err = c.fs.NetTCP()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseProcTCPStats(sockets), nil
+}
+
+func getInetDiagTCPStats(family uint8) (map[tcpConnectionState]float64, error) {
 	const TCPFAll = 0xFFF
 	const InetDiagInfo = 2
 	const SockDiagByFamily = 20
 
-	conn, err := netlink.Dial(syscall.NETLINK_INET_DIAG, nil)
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_INET_DIAG)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't connect netlink: %w", err)
 	}
-	defer conn.Close()
-
-	msg := netlink.Message{
-		Header: netlink.Header{
-			Type:  SockDiagByFamily,
-			Flags: syscall.NLM_F_REQUEST | syscall.NLM_F_DUMP,
-		},
-		Data: (&InetDiagReqV2{
-			Family:   family,
-			Protocol: syscall.IPPROTO_TCP,
-			States:   TCPFAll,
-			Ext:      0 | 1<<(InetDiagInfo-1),
-		}).Serialize(),
-	}
+	defer unix.Close(fd)
 
-	messages, err := conn.Execute(msg)
-	if err != nil {
-		return nil, err
+	req := (&InetDiagReqV2{
+		Family:   family,
+		Protocol: syscall.IPPROTO_TCP,
+		States:   TCPFAll,
+		Ext:      0 | 1<<(InetDiagInfo-1),
+	}).Serialize()
+	msg := make([]byte, unix.NLMSG_HDRLEN+len(req))
+	native.Endian.PutUint32(msg[0:4], uint32(len(msg)))
+	native.Endian.PutUint16(msg[4:6], SockDiagByFamily)
+	native.Endian.PutUint16(msg[6:8], unix.NLM_F_REQUEST|unix.NLM_F_DUMP)
+	copy(msg[unix.NLMSG_HDRLEN:], req)
+
+	if err := unix.Sendto(fd, msg, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, fmt.Errorf("couldn't send inet_diag request: %w", err)
 	}
 
-	return parseTCPStats(messages)
+	return parseTCPStats(func(b []byte) (int, error) {
+		n, _, err := unix.Recvfrom(fd, b, 0)
+		return n, err
+	})
 }
 
-func parseTCPStats(msgs []netlink.Message) (map[tcpConnectionState]float64, error) {
+// inetDiagBufferSize is large enough for the datagrams of an inet_diag
+// dump, which the kernel caps at 32KiB.
+const inetDiagBufferSize = 32 * 1024
+
+// parseTCPStats counts the sockets of an inet_diag dump as recv reads it,
+// one datagram at a time, so that the dump is never held in memory as a
+// whole.
+func parseTCPStats(recv func([]byte) (int, error)) (map[tcpConnectionState]float64, error) {
 	tcpStats := map[tcpConnectionState]float64{}
+	buf := make([]byte, inetDiagBufferSize)
 
-	for _, m := range msgs {
-		msg := parseInetDiagMsg(m.Data)
+	for {
+		n, err := recv(buf)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, errors.New("inet_diag dump ended without NLMSG_DONE")
+		}
+
+		for b := buf[:n]; len(b) >= unix.NLMSG_HDRLEN; {
+			l := int(native.Endian.Uint32(b[0:4]))
+			if l < unix.NLMSG_HDRLEN || l > len(b) {
+				return nil, fmt.Errorf("invalid netlink message length %d", l)
+			}
+			data := b[unix.NLMSG_HDRLEN:l]
+
+			switch native.Endian.Uint16(b[4:6]) {
+			case unix.NLMSG_DONE:
+				return tcpStats, nil
+			case unix.NLMSG_ERROR:
+				if len(data) < 4 {
+					return nil, errors.New("short netlink error message")
+				}
+				if errno := -int32(native.Endian.Uint32(data[0:4])); errno != 0 {
+					return nil, syscall.Errno(errno)
+				}
+			default:
+				if len(data) < int(unsafe.Sizeof(InetDiagMsg{})) {
+					return nil, fmt.Errorf("short inet_diag message of %d bytes", len(data))
+				}
+				msg := parseInetDiagMsg(data)
+
+				tcpStats[tcpTxQueuedBytes] += float64(msg.WQueue)
+				tcpStats[tcpRxQueuedBytes] += float64(msg.RQueue)
+				tcpStats[tcpConnectionState(msg.State)]++
+			}
+
+			if l = nlmsgAlign(l); l > len(b) {
+				l = len(b)
+			}
+			b = b[l:]
+		}
+	}
+}
+
+func nlmsgAlign(l int) int {
+	return (l + unix.NLMSG_ALIGNTO - 1) &^ (unix.NLMSG_ALIGNTO - 1)
+}
+
+func parseProcTCPStats(sockets procfs.NetTCP) map[tcpConnectionState]float64 {
+	tcpStats := map[tcpConnectionState]float64{}
 
-		tcpStats[tcpTxQueuedBytes] += float64(msg.WQueue)
-		tcpStats[tcpRxQueuedBytes] += float64(msg.RQueue)
-		tcpStats[tcpConnectionState(msg.State)]++
+	for _, s := range sockets {
+		tcpStats[tcpTxQueuedBytes] += float64(s.TxQueue)
+		tcpStats[tcpRxQueuedBytes] += float64(s.RxQueue)
+		tcpStats[tcpConnectionState(s.St)]++
 	}
 
-	return tcpStats, nil
+	return tcpStats
 }
 
 func (st tcpConnectionState) String() string {
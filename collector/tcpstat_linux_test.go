@@ -23,51 +23,83 @@ import (
 	"testing"
 
 	"github.com/josharian/native"
-	"github.com/mdlayher/netlink"
+	"github.com/prometheus/procfs"
 )
 
-func Test_parseTCPStats(t *testing.T) {
-	encode := func(m InetDiagMsg) []byte {
+// inetDiagDump returns recv reading the netlink messages of msgs, followed
+// by NLMSG_DONE, in datagrams of at most inetDiagBufferSize bytes.
+func inetDiagDump(msgs []InetDiagMsg) func([]byte) (int, error) {
+	encode := func(typ uint16, data []byte) []byte {
 		var buf bytes.Buffer
-		err := binary.Write(&buf, native.Endian, m)
+		err := binary.Write(&buf, native.Endian, syscall.NlMsghdr{
+			Len:  uint32(syscall.NLMSG_HDRLEN + len(data)),
+			Type: typ,
+		})
 		if err != nil {
 			panic(err)
 		}
+		buf.Write(data)
 		return buf.Bytes()
 	}
 
-	msg := []netlink.Message{
+	var datagrams [][]byte
+	var datagram []byte
+	add := func(m []byte) {
+		if len(datagram)+len(m) > inetDiagBufferSize {
+			datagrams = append(datagrams, datagram)
+			datagram = nil
+		}
+		datagram = append(datagram, m...)
+	}
+	for _, m := range msgs {
+		var buf bytes.Buffer
+		if err := binary.Write(&buf, native.Endian, m); err != nil {
+			panic(err)
+		}
+		add(encode(20, buf.Bytes()))
+	}
+	add(encode(syscall.NLMSG_DONE, make([]byte, 4)))
+	datagrams = append(datagrams, datagram)
+
+	return func(b []byte) (int, error) {
+		if len(datagrams) == 0 {
+			return 0, nil
+		}
+		n := copy(b, datagrams[0])
+		datagrams = datagrams[1:]
+		return n, nil
+	}
+}
+
+func Test_parseTCPStats(t *testing.T) {
+	msgs := []InetDiagMsg{
 		{
-			Data: encode(InetDiagMsg{
-				Family:  syscall.AF_INET,
-				State:   uint8(tcpEstablished),
-				Timer:   0,
-				Retrans: 0,
-				ID:      InetDiagSockID{},
-				Expires: 0,
-				RQueue:  11,
-				WQueue:  21,
-				UID:     0,
-				Inode:   0,
-			}),
+			Family:  syscall.AF_INET,
+			State:   uint8(tcpEstablished),
+			Timer:   0,
+			Retrans: 0,
+			ID:      InetDiagSockID{},
+			Expires: 0,
+			RQueue:  11,
+			WQueue:  21,
+			UID:     0,
+			Inode:   0,
 		},
 		{
-			Data: encode(InetDiagMsg{
-				Family:  syscall.AF_INET,
-				State:   uint8(tcpListen),
-				Timer:   0,
-				Retrans: 0,
-				ID:      InetDiagSockID{},
-				Expires: 0,
-				RQueue:  11,
-				WQueue:  21,
-				UID:     0,
-				Inode:   0,
-			}),
+			Family:  syscall.AF_INET,
+			State:   uint8(tcpListen),
+			Timer:   0,
+			Retrans: 0,
+			ID:      InetDiagSockID{},
+			Expires: 0,
+			RQueue:  11,
+			WQueue:  21,
+			UID:     0,
+			Inode:   0,
 		},
 	}
 
-	tcpStats, err := parseTCPStats(msg)
+	tcpStats, err := parseTCPStats(inetDiagDump(msgs))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -87,4 +119,53 @@ func Test_parseTCPStats(t *testing.T) {
 		t.Errorf("want tcpstat number of bytes in rx queue %d, got %d", want, got)
 	}
 
+	if _, err := parseTCPStats(func([]byte) (int, error) { return 0, nil }); err == nil {
+		t.Error("want error for a dump without NLMSG_DONE")
+	}
+}
+
+func Test_parseProcTCPStats(t *testing.T) {
+	fs, err := procfs.NewFS("fixtures/proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sockets, err := fs.NetTCP()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tcpStats := parseProcTCPStats(sockets)
+	for st, want := range map[tcpConnectionState]int{
+		tcpListen:        2,
+		tcpEstablished:   1,
+		tcpTxQueuedBytes: 21,
+		tcpRxQueuedBytes: 1,
+	} {
+		if got := int(tcpStats[st]); want != got {
+			t.Errorf("want tcpstat %s %d, got %d", st, want, got)
+		}
+	}
+}
+
+func BenchmarkParseTCPStats(b *testing.B) {
+	msgs := make([]InetDiagMsg, 100000)
+	for i := range msgs {
+		msgs[i] = InetDiagMsg{
+			Family: syscall.AF_INET,
+			State:  uint8(tcpEstablished),
+			RQueue: 1,
+			WQueue: 2,
+		}
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		recv := inetDiagDump(msgs)
+		b.StartTimer()
+
+		if _, err := parseTCPStats(recv); err != nil {
+			b.Fatal(err)
+		}
+	}
 }
@@ -33,18 +33,27 @@ import (
 
 var (
 	textFileDirectories = kingpin.Flag("collector.textfile.directory", "Directory to read text files with metrics from, supports glob matching. (repeatable)").Default("").Strings()
+	textFileStaleness   = kingpin.Flag("collector.textfile.staleness", "Expose the seconds since the last modification of each text file.").Default("false").Bool()
 	mtimeDesc           = prometheus.NewDesc(
 		"node_textfile_mtime_seconds",
 		"Unixtime mtime of textfiles successfully read.",
 		[]string{"file"},
 		nil,
 	)
+	stalenessDesc = prometheus.NewDesc(
+		"node_textfile_staleness_seconds",
+		"Seconds since the last modification of textfiles successfully read.",
+		[]string{"file"},
+		nil,
+	)
 )
 
 type textFileCollector struct {
-	paths []string
+	paths     []string
+	staleness bool
 	// Only set for testing to get predictable output.
 	mtime  *float64
+	now    func() time.Time
 	logger *slog.Logger
 }
 
@@ -56,8 +65,10 @@ func init() {
 // in the given textfile directory.
 func NewTextFileCollector(logger *slog.Logger) (Collector, error) {
 	c := &textFileCollector{
-		paths:  *textFileDirectories,
-		logger: logger,
+		paths:     *textFileDirectories,
+		staleness: *textFileStaleness,
+		now:       time.Now,
+		logger:    logger,
 	}
 	return c, nil
 }
@@ -183,6 +194,14 @@ func (c *textFileCollector) exportMTimes(mtimes map[string]time.Time, ch chan<-
 		}
 		ch <- prometheus.MustNewConstMetric(mtimeDesc, prometheus.GaugeValue, mtime, path)
 	}
+
+	if !c.staleness {
+		return
+	}
+	now := c.now()
+	for _, path := range filepaths {
+		ch <- prometheus.MustNewConstMetric(stalenessDesc, prometheus.GaugeValue, now.Sub(mtimes[path]).Seconds(), path)
+	}
 }
 
 // Update implements the Collector interface.
@@ -193,6 +212,7 @@ func (c *textFileCollector) Update(ch chan<- prometheus.Metric) error {
 	var parsedFamilies []*dto.MetricFamily
 	metricsNamesToFiles := map[string][]string{}
 	metricsNamesToHelpTexts := map[string][2]string{}
+	seriesToFiles := map[string]string{}
 
 	paths := []string{}
 	for _, glob := range c.paths {
@@ -220,6 +240,21 @@ func (c *textFileCollector) Update(ch chan<- prometheus.Metric) error {
 			}
 
 			mtime, families, err := c.processFile(path, f.Name(), ch)
+			if err == nil {
+				// The registry can't gather the same series twice, so reject
+				// the later file instead of failing the whole scrape.
+				if series, file, dup := duplicateSeries(families, seriesToFiles); dup {
+					errored = true
+					c.logger.Error("duplicate metric, skipping entire file",
+						"series", series,
+						"file", metricsFilePath,
+						"previous_file", file)
+					continue
+				}
+				for _, series := range seriesKeys(families) {
+					seriesToFiles[series] = metricsFilePath
+				}
+			}
 
 			for _, mf := range families {
 				// Check for metrics with inconsistent help texts and take the first help text occurrence.
@@ -299,6 +334,11 @@ func (c *textFileCollector) processFile(dir, name string, ch chan<- prometheus.M
 	}
 	defer f.Close()
 
+	before, err := f.Stat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to stat %q: %w", path, err)
+	}
+
 	var parser expfmt.TextParser
 	families, err := parser.TextToMetricFamilies(f)
 	if err != nil {
@@ -316,10 +356,50 @@ func (c *textFileCollector) processFile(dir, name string, ch chan<- prometheus.M
 		return nil, families, fmt.Errorf("failed to stat %q: %w", path, err)
 	}
 
+	// A file written in place instead of renamed into the directory may have
+	// been parsed half-written.
+	if stat.Size() != before.Size() || !stat.ModTime().Equal(before.ModTime()) {
+		return nil, nil, fmt.Errorf("textfile %q changed while being read, skipping entire file", path)
+	}
+
 	t := stat.ModTime()
 	return &t, families, nil
 }
 
+// seriesKeys returns a key for each series of the families, made of the
+// metric name and its sorted label pairs.
+func seriesKeys(families map[string]*dto.MetricFamily) []string {
+	var keys []string
+	for name, mf := range families {
+		for _, m := range mf.Metric {
+			labels := make([]string, 0, len(m.Label))
+			for _, l := range m.Label {
+				labels = append(labels, fmt.Sprintf("%s=%q", l.GetName(), l.GetValue()))
+			}
+			sort.Strings(labels)
+			keys = append(keys, name+"{"+strings.Join(labels, ",")+"}")
+		}
+	}
+	return keys
+}
+
+// duplicateSeries returns the first series of the families that was read
+// before, along with the file it was read from, or is repeated among the
+// families themselves.
+func duplicateSeries(families map[string]*dto.MetricFamily, seriesToFiles map[string]string) (string, string, bool) {
+	seen := map[string]struct{}{}
+	for _, series := range seriesKeys(families) {
+		if file, ok := seriesToFiles[series]; ok {
+			return series, file, true
+		}
+		if _, ok := seen[series]; ok {
+			return series, "", true
+		}
+		seen[series] = struct{}{}
+	}
+	return "", "", false
+}
+
 // hasTimestamps returns true when metrics contain unsupported timestamps.
 func hasTimestamps(parsedFamilies map[string]*dto.MetricFamily) bool {
 	for _, mf := range parsedFamilies {
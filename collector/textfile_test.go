@@ -23,11 +23,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/common/promslog"
 	"github.com/prometheus/common/promslog/flag"
 )
@@ -122,6 +126,10 @@ func TestTextfileCollector(t *testing.T) {
 			paths: []string{"fixtures/textfile/metrics_merge_different_help"},
 			out:   "fixtures/textfile/metrics_merge_different_help.out",
 		},
+		{
+			paths: []string{"fixtures/textfile/duplicate_series"},
+			out:   "fixtures/textfile/duplicate_series.out",
+		},
 	}
 
 	for i, test := range tests {
@@ -157,3 +165,35 @@ func TestTextfileCollector(t *testing.T) {
 		}
 	}
 }
+
+func TestTextfileStaleness(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cron.prom")
+	if err := os.WriteFile(path, []byte("cron_last_run 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Unix(1700000000, 0)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &textFileCollector{
+		paths:     []string{dir},
+		staleness: true,
+		now:       func() time.Time { return mtime.Add(90 * time.Second) },
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	want := fmt.Sprintf(`# HELP node_textfile_mtime_seconds Unixtime mtime of textfiles successfully read.
+# TYPE node_textfile_mtime_seconds gauge
+node_textfile_mtime_seconds{file=%[1]q} 1.7e+09
+# HELP node_textfile_staleness_seconds Seconds since the last modification of textfiles successfully read.
+# TYPE node_textfile_staleness_seconds gauge
+node_textfile_staleness_seconds{file=%[1]q} 90
+`, path)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectorAdapter{c})
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want), "node_textfile_mtime_seconds", "node_textfile_staleness_seconds"); err != nil {
+		t.Fatal(err)
+	}
+}
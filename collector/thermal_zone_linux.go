@@ -21,6 +21,9 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs/sysfs"
@@ -34,6 +37,7 @@ type thermalZoneCollector struct {
 	coolingDeviceCurState *prometheus.Desc
 	coolingDeviceMaxState *prometheus.Desc
 	zoneTemp              *prometheus.Desc
+	zoneTripPoint         *prometheus.Desc
 	logger                *slog.Logger
 }
 
@@ -55,6 +59,11 @@ func NewThermalZoneCollector(logger *slog.Logger) (Collector, error) {
 			"Zone temperature in Celsius",
 			[]string{"zone", "type"}, nil,
 		),
+		zoneTripPoint: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, thermalZone, "trip_point_celsius"),
+			"Zone trip point temperature in Celsius",
+			[]string{"zone", "trip", "type"}, nil,
+		),
 		coolingDeviceCurState: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, coolingDevice, "cur_state"),
 			"Current throttle state of the cooling device",
@@ -72,7 +81,7 @@ func NewThermalZoneCollector(logger *slog.Logger) (Collector, error) {
 func (c *thermalZoneCollector) Update(ch chan<- prometheus.Metric) error {
 	thermalZones, err := c.fs.ClassThermalZoneStats()
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) || errors.Is(err, os.ErrInvalid) {
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) || errors.Is(err, os.ErrInvalid) || errors.Is(err, syscall.EINVAL) {
 			c.logger.Debug("Could not read thermal zone stats", "err", err)
 			return ErrNoData
 		}
@@ -87,30 +96,91 @@ func (c *thermalZoneCollector) Update(ch chan<- prometheus.Metric) error {
 			stats.Name,
 			stats.Type,
 		)
+		c.updateTripPoints(ch, stats.Name)
 	}
 
-	coolingDevices, err := c.fs.ClassCoolingDeviceStats()
+	devices, err := filepath.Glob(sysFilePath("class/thermal/" + coolingDevice + "[0-9]*"))
 	if err != nil {
 		return err
 	}
+	for _, device := range devices {
+		name := strings.TrimPrefix(filepath.Base(device), coolingDevice)
+		cdType, maxState, curState, err := readCoolingDevice(device)
+		if err != nil {
+			// Some drivers return EINVAL for the state of idle devices, only
+			// skip the device instead of all of them.
+			if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) || errors.Is(err, syscall.EINVAL) {
+				c.logger.Debug("Could not read cooling device stats", "device", name, "err", err)
+				continue
+			}
+			return err
+		}
 
-	for _, stats := range coolingDevices {
 		ch <- prometheus.MustNewConstMetric(
 			c.coolingDeviceCurState,
 			prometheus.GaugeValue,
-			float64(stats.CurState),
-			stats.Name,
-			stats.Type,
+			float64(curState),
+			name,
+			cdType,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.coolingDeviceMaxState,
 			prometheus.GaugeValue,
-			float64(stats.MaxState),
-			stats.Name,
-			stats.Type,
+			float64(maxState),
+			name,
+			cdType,
 		)
 	}
 
 	return nil
 }
+
+// readCoolingDevice reads a single cooling device, as procfs only parses all
+// of them at once and fails on the first error. cur_state can be -1, e.g. for
+// intel_powerclamp.
+func readCoolingDevice(device string) (cdType string, maxState, curState int64, err error) {
+	data, err := os.ReadFile(filepath.Join(device, "type"))
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if maxState, err = readIntFromFile(filepath.Join(device, "max_state")); err != nil {
+		return "", 0, 0, err
+	}
+	if curState, err = readIntFromFile(filepath.Join(device, "cur_state")); err != nil {
+		return "", 0, 0, err
+	}
+	return strings.TrimSpace(string(data)), maxState, curState, nil
+}
+
+// updateTripPoints exposes the trip points of a zone. procfs doesn't parse
+// them, so they are read from sysfs directly. Zones without trip points are
+// skipped.
+func (c *thermalZoneCollector) updateTripPoints(ch chan<- prometheus.Metric, zone string) {
+	temps, err := filepath.Glob(sysFilePath(filepath.Join("class/thermal", thermalZone+zone, "trip_point_*_temp")))
+	if err != nil {
+		c.logger.Debug("Could not list trip points", "zone", zone, "err", err)
+		return
+	}
+	for _, tempFile := range temps {
+		trip := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(tempFile), "trip_point_"), "_temp")
+		temp, err := readIntFromFile(tempFile)
+		if err != nil {
+			c.logger.Debug("Could not read trip point temperature", "zone", zone, "trip", trip, "err", err)
+			continue
+		}
+		tripType, err := os.ReadFile(strings.TrimSuffix(tempFile, "_temp") + "_type")
+		if err != nil {
+			c.logger.Debug("Could not read trip point type", "zone", zone, "trip", trip, "err", err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.zoneTripPoint,
+			prometheus.GaugeValue,
+			float64(temp)/1000.0,
+			zone,
+			trip,
+			strings.TrimSpace(string(tripType)),
+		)
+	}
+}
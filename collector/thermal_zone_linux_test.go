@@ -0,0 +1,109 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nothermalzone
+// +build !nothermalzone
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testThermalZoneCollector struct {
+	tc Collector
+}
+
+func (c testThermalZoneCollector) Collect(ch chan<- prometheus.Metric) {
+	c.tc.Update(ch)
+}
+
+func (c testThermalZoneCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestThermalZone(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	tc, err := NewThermalZoneCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_cooling_device_cur_state Current throttle state of the cooling device
+		# TYPE node_cooling_device_cur_state gauge
+		node_cooling_device_cur_state{name="0",type="Processor"} 0
+		# HELP node_cooling_device_max_state Maximum throttle state of the cooling device
+		# TYPE node_cooling_device_max_state gauge
+		node_cooling_device_max_state{name="0",type="Processor"} 3
+		# HELP node_thermal_zone_temp Zone temperature in Celsius
+		# TYPE node_thermal_zone_temp gauge
+		node_thermal_zone_temp{type="cpu-thermal",zone="0"} 12.376
+		# HELP node_thermal_zone_trip_point_celsius Zone trip point temperature in Celsius
+		# TYPE node_thermal_zone_trip_point_celsius gauge
+		node_thermal_zone_trip_point_celsius{trip="0",type="critical",zone="0"} 105
+		node_thermal_zone_trip_point_celsius{trip="1",type="passive",zone="0"} 85
+`
+	if err := testutil.CollectAndCompare(testThermalZoneCollector{tc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestThermalZoneSkipsUnreadableCoolingDevice(t *testing.T) {
+	sys := t.TempDir()
+	for file, content := range map[string]string{
+		"class/thermal/cooling_device0/type":      "Processor\n",
+		"class/thermal/cooling_device0/max_state": "3\n",
+		"class/thermal/cooling_device0/cur_state": "1\n",
+		// cur_state of cooling_device1 couldn't be read.
+		"class/thermal/cooling_device1/type":      "Fan\n",
+		"class/thermal/cooling_device1/max_state": "1\n",
+	} {
+		path := filepath.Join(sys, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", sys}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *sysPath = "fixtures/sys" }()
+	tc, err := NewThermalZoneCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_cooling_device_cur_state Current throttle state of the cooling device
+		# TYPE node_cooling_device_cur_state gauge
+		node_cooling_device_cur_state{name="0",type="Processor"} 1
+		# HELP node_cooling_device_max_state Maximum throttle state of the cooling device
+		# TYPE node_cooling_device_max_state gauge
+		node_cooling_device_max_state{name="0",type="Processor"} 3
+`
+	if err := testutil.CollectAndCompare(testThermalZoneCollector{tc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
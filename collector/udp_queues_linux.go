@@ -28,9 +28,10 @@ import (
 
 type (
 	udpQueuesCollector struct {
-		fs     procfs.FS
-		desc   *prometheus.Desc
-		logger *slog.Logger
+		fs           procfs.FS
+		desc         *prometheus.Desc
+		droppingDesc *prometheus.Desc
+		logger       *slog.Logger
 	}
 )
 
@@ -51,16 +52,20 @@ func NewUDPqueuesCollector(logger *slog.Logger) (Collector, error) {
 			"Number of allocated memory in the kernel for UDP datagrams in bytes.",
 			[]string{"queue", "ip"}, nil,
 		),
+		droppingDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "udp", "sockets_dropping"),
+			"Number of open UDP sockets which dropped datagrams, e.g. because their receive queue was full.",
+			[]string{"ip"}, nil,
+		),
 		logger: logger,
 	}, nil
 }
 
 func (c *udpQueuesCollector) Update(ch chan<- prometheus.Metric) error {
 
-	s4, errIPv4 := c.fs.NetUDPSummary()
+	s4, errIPv4 := c.fs.NetUDP()
 	if errIPv4 == nil {
-		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(s4.TxQueueLength), "tx", "v4")
-		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(s4.RxQueueLength), "rx", "v4")
+		c.updateSockets(ch, s4, "v4")
 	} else {
 		if errors.Is(errIPv4, os.ErrNotExist) {
 			c.logger.Debug("not collecting ipv4 based metrics")
@@ -69,10 +74,9 @@ func (c *udpQueuesCollector) Update(ch chan<- prometheus.Metric) error {
 		}
 	}
 
-	s6, errIPv6 := c.fs.NetUDP6Summary()
+	s6, errIPv6 := c.fs.NetUDP6()
 	if errIPv6 == nil {
-		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(s6.TxQueueLength), "tx", "v6")
-		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(s6.RxQueueLength), "rx", "v6")
+		c.updateSockets(ch, s6, "v6")
 	} else {
 		if errors.Is(errIPv6, os.ErrNotExist) {
 			c.logger.Debug("not collecting ipv6 based metrics")
@@ -86,3 +90,26 @@ func (c *udpQueuesCollector) Update(ch chan<- prometheus.Metric) error {
 	}
 	return nil
 }
+
+// updateSockets exposes the queued bytes of the sockets and how many of them
+// dropped datagrams. The sum of the drops isn't exposed as it goes down when
+// sockets are closed.
+func (c *udpQueuesCollector) updateSockets(ch chan<- prometheus.Metric, sockets procfs.NetUDP, ip string) {
+	var tx, rx, dropping uint64
+	hasDrops := false
+	for _, s := range sockets {
+		tx += s.TxQueue
+		rx += s.RxQueue
+		if s.Drops != nil {
+			hasDrops = true
+			if *s.Drops > 0 {
+				dropping++
+			}
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(tx), "tx", ip)
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(rx), "rx", ip)
+	if hasDrops {
+		ch <- prometheus.MustNewConstMetric(c.droppingDesc, prometheus.GaugeValue, float64(dropping), ip)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noudp_queues
+// +build !noudp_queues
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testUDPQueuesCollector struct {
+	uc Collector
+}
+
+func (c testUDPQueuesCollector) Collect(ch chan<- prometheus.Metric) {
+	c.uc.Update(ch)
+}
+
+func (c testUDPQueuesCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestUDPQueues(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	uc, err := NewUDPqueuesCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_udp_queues Number of allocated memory in the kernel for UDP datagrams in bytes.
+		# TYPE node_udp_queues gauge
+		node_udp_queues{ip="v4",queue="rx"} 2560
+		node_udp_queues{ip="v4",queue="tx"} 21
+		node_udp_queues{ip="v6",queue="rx"} 256
+		node_udp_queues{ip="v6",queue="tx"} 0
+		# HELP node_udp_sockets_dropping Number of open UDP sockets which dropped datagrams, e.g. because their receive queue was full.
+		# TYPE node_udp_sockets_dropping gauge
+		node_udp_sockets_dropping{ip="v4"} 1
+		node_udp_sockets_dropping{ip="v6"} 1
+`
+	if err := testutil.CollectAndCompare(testUDPQueuesCollector{uc}, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
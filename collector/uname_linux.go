@@ -24,7 +24,12 @@ func getUname() (uname, error) {
 		return uname{}, err
 	}
 
-	output := uname{
+	return parseUtsname(utsname), nil
+}
+
+// parseUtsname converts the NUL terminated fields of utsname.
+func parseUtsname(utsname unix.Utsname) uname {
+	return uname{
 		SysName:    unix.ByteSliceToString(utsname.Sysname[:]),
 		Release:    unix.ByteSliceToString(utsname.Release[:]),
 		Version:    unix.ByteSliceToString(utsname.Version[:]),
@@ -32,6 +37,4 @@ func getUname() (uname, error) {
 		NodeName:   unix.ByteSliceToString(utsname.Nodename[:]),
 		DomainName: unix.ByteSliceToString(utsname.Domainname[:]),
 	}
-
-	return output, nil
 }
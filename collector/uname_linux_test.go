@@ -0,0 +1,46 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nouname
+// +build !nouname
+
+package collector
+
+import (
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestParseUtsname(t *testing.T) {
+	var utsname unix.Utsname
+	copy(utsname.Sysname[:], "Linux")
+	copy(utsname.Release[:], "6.8.0-45-generic")
+	copy(utsname.Version[:], "#45-Ubuntu SMP PREEMPT_DYNAMIC Fri Aug 30 12:02:04 UTC 2024")
+	copy(utsname.Machine[:], "x86_64")
+	copy(utsname.Nodename[:], "node1")
+	// Fields are NUL terminated, the rest of the buffer must be ignored.
+	copy(utsname.Domainname[:], "(none)\x00garbage")
+
+	want := uname{
+		SysName:    "Linux",
+		Release:    "6.8.0-45-generic",
+		Version:    "#45-Ubuntu SMP PREEMPT_DYNAMIC Fri Aug 30 12:02:04 UTC 2024",
+		Machine:    "x86_64",
+		NodeName:   "node1",
+		DomainName: "(none)",
+	}
+	if got := parseUtsname(utsname); got != want {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
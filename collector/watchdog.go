@@ -21,13 +21,13 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/procfs/sysfs"
 )
 
 type watchdogCollector struct {
-	fs     sysfs.FS
 	logger *slog.Logger
 }
 
@@ -37,13 +37,7 @@ func init() {
 
 // NewWatchdogCollector returns a new Collector exposing watchdog stats.
 func NewWatchdogCollector(logger *slog.Logger) (Collector, error) {
-	fs, err := sysfs.NewFS(*sysPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open procfs: %w", err)
-	}
-
 	return &watchdogCollector{
-		fs:     fs,
 		logger: logger,
 	}, nil
 }
@@ -91,49 +85,66 @@ var (
 	)
 )
 
-func toLabelValue(ptr *string) string {
-	if ptr == nil {
-		return ""
-	}
-	return *ptr
+// watchdogAttributes are the numeric attributes of a watchdog device.
+var watchdogAttributes = []struct {
+	file string
+	desc *prometheus.Desc
+}{
+	{"access_cs0", watchdogAccessCs0Desc},
+	{"bootstatus", watchdogBootstatusDesc},
+	{"fw_version", watchdogFwVersionDesc},
+	{"nowayout", watchdogNowayoutDesc},
+	{"pretimeout", watchdogPretimeoutDesc},
+	{"timeleft", watchdogTimeleftDesc},
+	{"timeout", watchdogTimeoutDesc},
 }
 
 func (c *watchdogCollector) Update(ch chan<- prometheus.Metric) error {
-	watchdogClass, err := c.fs.WatchdogClass()
+	classPath := sysFilePath("class/watchdog")
+	devices, err := os.ReadDir(classPath)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) || errors.Is(err, os.ErrInvalid) {
 			c.logger.Debug("Could not read watchdog stats", "err", err)
 			return ErrNoData
 		}
-		return err
+		return fmt.Errorf("failed to list watchdog devices at %q: %w", classPath, err)
 	}
 
-	for _, wd := range watchdogClass {
-		if wd.Bootstatus != nil {
-			ch <- prometheus.MustNewConstMetric(watchdogBootstatusDesc, prometheus.GaugeValue, float64(*wd.Bootstatus), wd.Name)
-		}
-		if wd.FwVersion != nil {
-			ch <- prometheus.MustNewConstMetric(watchdogFwVersionDesc, prometheus.GaugeValue, float64(*wd.FwVersion), wd.Name)
-		}
-		if wd.Nowayout != nil {
-			ch <- prometheus.MustNewConstMetric(watchdogNowayoutDesc, prometheus.GaugeValue, float64(*wd.Nowayout), wd.Name)
-		}
-		if wd.Timeleft != nil {
-			ch <- prometheus.MustNewConstMetric(watchdogTimeleftDesc, prometheus.GaugeValue, float64(*wd.Timeleft), wd.Name)
-		}
-		if wd.Timeout != nil {
-			ch <- prometheus.MustNewConstMetric(watchdogTimeoutDesc, prometheus.GaugeValue, float64(*wd.Timeout), wd.Name)
-		}
-		if wd.Pretimeout != nil {
-			ch <- prometheus.MustNewConstMetric(watchdogPretimeoutDesc, prometheus.GaugeValue, float64(*wd.Pretimeout), wd.Name)
-		}
-		if wd.AccessCs0 != nil {
-			ch <- prometheus.MustNewConstMetric(watchdogAccessCs0Desc, prometheus.GaugeValue, float64(*wd.AccessCs0), wd.Name)
+	for _, device := range devices {
+		name := device.Name()
+		path := filepath.Join(classPath, name)
+
+		// Attributes depend on the driver, and some, like timeleft, can only
+		// be read while the watchdog is open. Skip just the attribute then.
+		for _, attr := range watchdogAttributes {
+			value, err := readIntFromFile(filepath.Join(path, attr.file))
+			if err != nil {
+				if !errors.Is(err, os.ErrNotExist) {
+					c.logger.Debug("Could not read watchdog attribute", "name", name, "attribute", attr.file, "err", err)
+				}
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(attr.desc, prometheus.GaugeValue, float64(value), name)
 		}
 
 		ch <- prometheus.MustNewConstMetric(watchdogInfoDesc, prometheus.GaugeValue, 1.0,
-			wd.Name, toLabelValue(wd.Options), toLabelValue(wd.Identity), toLabelValue(wd.State), toLabelValue(wd.Status), toLabelValue(wd.PretimeoutGovernor))
+			name,
+			readWatchdogString(path, "options"),
+			readWatchdogString(path, "identity"),
+			readWatchdogString(path, "state"),
+			readWatchdogString(path, "status"),
+			readWatchdogString(path, "pretimeout_governor"))
 	}
 
 	return nil
 }
+
+// readWatchdogString returns the value of a string attribute, or an empty
+// string if the device doesn't have it.
+func readWatchdogString(path, attr string) string {
+	value, err := os.ReadFile(filepath.Join(path, attr))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(value))
+}
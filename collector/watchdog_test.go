@@ -17,9 +17,12 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -90,3 +93,42 @@ func TestWatchdogStats(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWatchdogUnreadableAttribute(t *testing.T) {
+	sysfs := t.TempDir()
+	dir := filepath.Join(sysfs, "class/watchdog/watchdog0")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "timeout"), []byte("30\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Reading timeleft fails, like it does for some drivers while the
+	// watchdog isn't open.
+	if err := os.Mkdir(filepath.Join(dir, "timeleft"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	*sysPath = sysfs
+	defer func() { *sysPath = "fixtures/sys" }()
+
+	c, err := NewWatchdogCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_watchdog_timeleft_seconds Value of /sys/class/watchdog/<watchdog>/timeleft
+	# TYPE node_watchdog_timeleft_seconds gauge
+	# HELP node_watchdog_timeout_seconds Value of /sys/class/watchdog/<watchdog>/timeout
+	# TYPE node_watchdog_timeout_seconds gauge
+	node_watchdog_timeout_seconds{name="watchdog0"} 30
+	`
+	if err := testutil.CollectAndCompare(testWatchdogCollector{c}, strings.NewReader(want),
+		"node_watchdog_timeleft_seconds", "node_watchdog_timeout_seconds"); err != nil {
+		t.Fatal(err)
+	}
+
+	*sysPath = filepath.Join(sysfs, "missing")
+	if err := c.Update(make(chan prometheus.Metric, 10)); !errors.Is(err, ErrNoData) {
+		t.Fatalf("want ErrNoData without watchdogs, got %v", err)
+	}
+}
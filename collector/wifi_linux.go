@@ -27,11 +27,15 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/mdlayher/wifi"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
 )
 
 type wifiCollector struct {
+	fs procfs.FS
+
 	interfaceFrequencyHertz *prometheus.Desc
 	stationInfo             *prometheus.Desc
+	stationConnected        *prometheus.Desc
 
 	stationConnectedSecondsTotal *prometheus.Desc
 	stationInactiveSeconds       *prometheus.Desc
@@ -44,7 +48,12 @@ type wifiCollector struct {
 	stationTransmitFailedTotal   *prometheus.Desc
 	stationBeaconLossTotal       *prometheus.Desc
 
-	logger *slog.Logger
+	linkQuality   *prometheus.Desc
+	linkSignalDBM *prometheus.Desc
+
+	// newStater is replaced in tests.
+	newStater func(fixtures string) (wifiStater, error)
+	logger    *slog.Logger
 }
 
 var (
@@ -75,7 +84,14 @@ func NewWifiCollector(logger *slog.Logger) (Collector, error) {
 		labels = []string{"device", "mac_address"}
 	)
 
+	fs, err := procfs.NewFS(*procPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open procfs: %w", err)
+	}
+
 	return &wifiCollector{
+		fs: fs,
+
 		interfaceFrequencyHertz: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, subsystem, "interface_frequency_hertz"),
 			"The current frequency a WiFi interface is operating at, in hertz.",
@@ -90,6 +106,13 @@ func NewWifiCollector(logger *slog.Logger) (Collector, error) {
 			nil,
 		),
 
+		stationConnected: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "station_connected"),
+			"Whether a WiFi interface in station mode is associated with an access point.",
+			[]string{"device"},
+			nil,
+		),
+
 		stationConnectedSecondsTotal: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, subsystem, "station_connected_seconds_total"),
 			"The total number of seconds a station has been connected to an access point.",
@@ -159,21 +182,47 @@ func NewWifiCollector(logger *slog.Logger) (Collector, error) {
 			labels,
 			nil,
 		),
-		logger: logger,
+
+		linkQuality: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "link_quality"),
+			"The link quality of a WiFi interface from /proc/net/wireless.",
+			[]string{"device"},
+			nil,
+		),
+
+		linkSignalDBM: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "link_signal_dbm"),
+			"The signal level of a WiFi interface from /proc/net/wireless, in decibel-milliwatts (dBm).",
+			[]string{"device"},
+			nil,
+		),
+		newStater: newWifiStater,
+		logger:    logger,
 	}, nil
 }
 
 func (c *wifiCollector) Update(ch chan<- prometheus.Metric) error {
-	stat, err := newWifiStater(*collectorWifi)
+	// /proc/net/wireless is also available where nl80211 isn't.
+	hasWireless := c.updateWirelessStats(ch)
+
+	stat, err := c.newStater(*collectorWifi)
 	if err != nil {
 		// Cannot access wifi metrics, report no error.
 		if errors.Is(err, os.ErrNotExist) {
 			c.logger.Debug("wifi collector metrics are not available for this system")
+			if hasWireless {
+				return nil
+			}
 			return ErrNoData
 		}
+		// Unlike a missing nl80211, this is a misconfiguration the collector
+		// failure should point out, unless /proc/net/wireless was exported.
 		if errors.Is(err, os.ErrPermission) {
-			c.logger.Debug("wifi collector got permission denied when accessing metrics")
-			return ErrNoData
+			if hasWireless {
+				c.logger.Warn("permission denied accessing nl80211, only exporting /proc/net/wireless statistics, the wifi collector may need to run as root or with CAP_NET_ADMIN", "err", err)
+				return nil
+			}
+			return fmt.Errorf("permission denied accessing nl80211, the wifi collector may need to run as root or with CAP_NET_ADMIN: %w", err)
 		}
 
 		return fmt.Errorf("failed to access wifi data: %w", err)
@@ -205,15 +254,23 @@ func (c *wifiCollector) Update(ch chan<- prometheus.Metric) error {
 		// metrics which are actually valid for given interface types.
 
 		bss, err := stat.BSS(ifi)
+		connected := 0.0
 		switch {
 		case err == nil:
 			c.updateBSSStats(ch, ifi.Name, bss)
+			if bss.Status == wifi.BSSStatusAssociated {
+				connected = 1
+			}
 		case errors.Is(err, os.ErrNotExist):
 			c.logger.Debug("BSS information not found for wifi device", "name", ifi.Name)
 		default:
 			return fmt.Errorf("failed to retrieve BSS for device %s: %v",
 				ifi.Name, err)
 		}
+		// Only stations associate with an access point.
+		if ifi.Type == wifi.InterfaceTypeStation {
+			ch <- prometheus.MustNewConstMetric(c.stationConnected, prometheus.GaugeValue, connected, ifi.Name)
+		}
 
 		stations, err := stat.StationInfo(ifi)
 		switch {
@@ -232,6 +289,24 @@ func (c *wifiCollector) Update(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
+// updateWirelessStats exposes the link statistics from /proc/net/wireless,
+// which only lists wireless interfaces. It returns false if there are none.
+func (c *wifiCollector) updateWirelessStats(ch chan<- prometheus.Metric) bool {
+	stats, err := c.fs.Wireless()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			c.logger.Debug("failed to read wireless statistics", "err", err)
+		}
+		return false
+	}
+
+	for _, s := range stats {
+		ch <- prometheus.MustNewConstMetric(c.linkQuality, prometheus.GaugeValue, float64(s.QualityLink), s.Name)
+		ch <- prometheus.MustNewConstMetric(c.linkSignalDBM, prometheus.GaugeValue, float64(s.QualityLevel), s.Name)
+	}
+	return len(stats) > 0
+}
+
 func (c *wifiCollector) updateBSSStats(ch chan<- prometheus.Metric, device string, bss *wifi.BSS) {
 	// Synthetic metric which provides wifi station info, such as SSID, BSSID, etc.
 	ch <- prometheus.MustNewConstMetric(
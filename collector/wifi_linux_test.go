@@ -0,0 +1,122 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nowifi
+// +build !nowifi
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/procfs"
+)
+
+type testWifiCollector struct {
+	wc Collector
+}
+
+func (c testWifiCollector) Collect(ch chan<- prometheus.Metric) {
+	c.wc.Update(ch)
+}
+
+func (c testWifiCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestWifiLinkStats(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc", "--collector.wifi.fixtures", "fixtures/wifi"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *collectorWifi = "" }()
+	wc, err := NewWifiCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_wifi_link_quality The link quality of a WiFi interface from /proc/net/wireless.
+		# TYPE node_wifi_link_quality gauge
+		node_wifi_link_quality{device="wlan0"} 54
+		# HELP node_wifi_link_signal_dbm The signal level of a WiFi interface from /proc/net/wireless, in decibel-milliwatts (dBm).
+		# TYPE node_wifi_link_signal_dbm gauge
+		node_wifi_link_signal_dbm{device="wlan0"} -56
+		# HELP node_wifi_station_connected Whether a WiFi interface in station mode is associated with an access point.
+		# TYPE node_wifi_station_connected gauge
+		node_wifi_station_connected{device="wlan0"} 1
+		# HELP node_wifi_station_signal_dbm The current WiFi signal strength, in decibel-milliwatts (dBm).
+		# TYPE node_wifi_station_signal_dbm gauge
+		node_wifi_station_signal_dbm{device="wlan0",mac_address="01:02:03:04:05:06"} -26
+		node_wifi_station_signal_dbm{device="wlan0",mac_address="aa:bb:cc:dd:ee:ff"} -52
+`
+	if err := testutil.CollectAndCompare(testWifiCollector{wc}, strings.NewReader(want),
+		"node_wifi_link_quality", "node_wifi_link_signal_dbm", "node_wifi_station_connected", "node_wifi_station_signal_dbm"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWifiPermissionDenied(t *testing.T) {
+	defer func() { *procPath = procfs.DefaultMountPoint }()
+	for _, test := range []struct {
+		name    string
+		procfs  string
+		wantErr bool
+	}{
+		{
+			name:   "with /proc/net/wireless",
+			procfs: "fixtures/proc",
+		},
+		{
+			name:    "without /proc/net/wireless",
+			procfs:  t.TempDir(),
+			wantErr: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", test.procfs}); err != nil {
+				t.Fatal(err)
+			}
+			wc, err := NewWifiCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			wc.(*wifiCollector).newStater = func(string) (wifiStater, error) {
+				return nil, fmt.Errorf("failed to open netlink: %w", os.ErrPermission)
+			}
+
+			ch := make(chan prometheus.Metric, 10)
+			err = wc.Update(ch)
+			close(ch)
+			if test.wantErr {
+				if err == nil || !errors.Is(err, os.ErrPermission) {
+					t.Errorf("expected permission error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected no error with /proc/net/wireless metrics, got %v", err)
+			}
+			if n := len(ch); n != 2 {
+				t.Errorf("want 2 /proc/net/wireless metrics, got %d", n)
+			}
+		})
+	}
+}
@@ -17,8 +17,10 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs/xfs"
@@ -54,6 +56,20 @@ func (c *xfsCollector) Update(ch chan<- prometheus.Metric) error {
 		return fmt.Errorf("failed to retrieve XFS stats: %w", err)
 	}
 
+	// Kernels before 4.4 don't have per-filesystem statistics in sysfs, only
+	// the sum over all filesystems in /proc/fs/xfs/stat. It is exported with
+	// an empty device label.
+	if _, err := os.Stat(sysFilePath("fs/xfs")); errors.Is(err, os.ErrNotExist) {
+		s, err := c.fs.ProcStat()
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to retrieve XFS stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
 	for _, s := range stats {
 		c.updateXFSStats(ch, s)
 	}
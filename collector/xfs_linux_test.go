@@ -0,0 +1,120 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !noxfs
+// +build !noxfs
+
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testXFSCollector struct {
+	xc Collector
+}
+
+func (c testXFSCollector) Collect(ch chan<- prometheus.Metric) {
+	c.xc.Update(ch)
+}
+
+func (c testXFSCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func TestXFS(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.sysfs", "fixtures/sys"}); err != nil {
+		t.Fatal(err)
+	}
+	xc, err := NewXFSCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_xfs_directory_operation_lookup_total Number of file name directory lookups which miss the operating systems directory name lookup cache.
+		# TYPE node_xfs_directory_operation_lookup_total counter
+		node_xfs_directory_operation_lookup_total{device="sda1"} 3
+		# HELP node_xfs_extent_allocation_blocks_allocated_total Number of blocks allocated for a filesystem.
+		# TYPE node_xfs_extent_allocation_blocks_allocated_total counter
+		node_xfs_extent_allocation_blocks_allocated_total{device="sda1"} 872
+		# HELP node_xfs_write_calls_total Number of write(2) system calls made to files in a filesystem.
+		# TYPE node_xfs_write_calls_total counter
+		node_xfs_write_calls_total{device="sda1"} 28
+`
+	if err := testutil.CollectAndCompare(testXFSCollector{xc}, strings.NewReader(want),
+		"node_xfs_directory_operation_lookup_total",
+		"node_xfs_extent_allocation_blocks_allocated_total",
+		"node_xfs_write_calls_total",
+	); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestXFSProcStat(t *testing.T) {
+	// Without per-filesystem statistics in sysfs the global ones are used.
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures/proc", "--path.sysfs", t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { *sysPath = "fixtures/sys" }()
+	xc, err := NewXFSCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP node_xfs_directory_operation_lookup_total Number of file name directory lookups which miss the operating systems directory name lookup cache.
+		# TYPE node_xfs_directory_operation_lookup_total counter
+		node_xfs_directory_operation_lookup_total{device=""} 185039
+		# HELP node_xfs_extent_allocation_blocks_allocated_total Number of blocks allocated for a filesystem.
+		# TYPE node_xfs_extent_allocation_blocks_allocated_total counter
+		node_xfs_extent_allocation_blocks_allocated_total{device=""} 97589
+		# HELP node_xfs_write_calls_total Number of write(2) system calls made to files in a filesystem.
+		# TYPE node_xfs_write_calls_total counter
+		node_xfs_write_calls_total{device=""} 107739
+`
+	if err := testutil.CollectAndCompare(testXFSCollector{xc}, strings.NewReader(want),
+		"node_xfs_directory_operation_lookup_total",
+		"node_xfs_extent_allocation_blocks_allocated_total",
+		"node_xfs_write_calls_total",
+	); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestXFSNotMounted(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", t.TempDir(), "--path.sysfs", t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		*procPath = "fixtures/proc"
+		*sysPath = "fixtures/sys"
+	}()
+	xc, err := NewXFSCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := make(chan prometheus.Metric, 10)
+	if err := xc.Update(ch); err != nil {
+		t.Fatalf("want no error without XFS, got %v", err)
+	}
+	if len(ch) != 0 {
+		t.Errorf("want no metrics, got %d", len(ch))
+	}
+}
@@ -83,12 +83,15 @@ func NewZFSCollector(logger *slog.Logger) (Collector, error) {
 }
 
 func (c *zfsCollector) Update(ch chan<- prometheus.Metric) error {
-	if _, err := c.openProcFile(c.linuxProcpathBase); err != nil {
+	dir, err := c.openProcFile(c.linuxProcpathBase)
+	if err != nil {
 		if err == errZFSNotAvailable {
 			c.logger.Debug(err.Error())
 			return ErrNoData
 		}
+		return err
 	}
+	dir.Close()
 
 	for subsystem := range c.linuxPathMap {
 		if err := c.updateZfsStats(subsystem, ch); err != nil {
@@ -17,9 +17,15 @@
 package collector
 
 import (
+	"errors"
+	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestArcstatsParsing(t *testing.T) {
@@ -605,3 +611,22 @@ func TestPoolStateParsing(t *testing.T) {
 	}
 
 }
+
+func TestZfsNotAvailable(t *testing.T) {
+	// fixtures_hidepid has no /proc/spl, like hosts without the spl module.
+	if _, err := kingpin.CommandLine.Parse([]string{"--path.procfs", "fixtures_hidepid/proc"}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewZFSCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := make(chan prometheus.Metric, 10)
+	if err := c.Update(ch); !errors.Is(err, ErrNoData) {
+		t.Fatalf("want ErrNoData, got %v", err)
+	}
+	if len(ch) != 0 {
+		t.Errorf("want no metrics, got %d", len(ch))
+	}
+}
@@ -55,7 +55,9 @@ enabled_collectors=$(cat << COLLECTORS
   hwmon
   infiniband
   interrupts
+  interrupts_sum
   ipvs
+  irqaffinity
   ksmd
   lnstat
   loadavg
@@ -105,7 +107,7 @@ cd "$(dirname $0)"
 port="$((10000 + (RANDOM % 10000)))"
 tmpdir=$(mktemp -d /tmp/node_exporter_e2e_test.XXXXXX)
 
-skip_re="^(go_|node_exporter_build_info|node_scrape_collector_duration_seconds|process_|node_textfile_mtime_seconds|node_time_(zone|seconds)|node_network_(receive|transmit)_(bytes|packets)_total)"
+skip_re="^(go_|node_exporter_build_info|node_exporter_scrape_duration_seconds|node_exporter_scrapes_in_flight|node_scrape_collector_duration_seconds|process_|node_textfile_mtime_seconds|node_time_(zone|seconds)|node_network_(receive|transmit)_(bytes|packets)_total)"
 
 case "${arch}" in
   aarch64|ppc64le) fixture_metrics='collector/fixtures/e2e-64k-page-output.txt' ;;
@@ -0,0 +1,138 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// jsonMetricFamily is the JSON representation of a metric family served for
+// ?format=json.
+type jsonMetricFamily struct {
+	Name    string       `json:"name"`
+	Type    string       `json:"type"`
+	Help    string       `json:"help"`
+	Samples []jsonSample `json:"samples"`
+}
+
+// jsonSample is a sample of the text format, e.g. a histogram is split into
+// its _bucket, _sum and _count samples. Like in the Prometheus HTTP API, the
+// value is a string, as JSON has no representation of NaN and infinities.
+type jsonSample struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+	Value  string            `json:"value"`
+}
+
+// serveJSON writes the metrics of gatherer as JSON. Like the text format
+// handler, it serves the metrics that could be gathered despite errors.
+func (h *handler) serveJSON(w http.ResponseWriter, gatherer prometheus.Gatherer) {
+	mfs, err := gatherer.Gather()
+	if err != nil {
+		h.logger.Error("error gathering metrics", "err", err)
+		if len(mfs) == 0 {
+			http.Error(w, "An error has occurred while gathering metrics:\n\n"+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	families := make([]jsonMetricFamily, 0, len(mfs))
+	for _, mf := range mfs {
+		families = append(families, metricFamilyToJSON(mf))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(families); err != nil {
+		h.logger.Error("error encoding metrics", "err", err)
+	}
+}
+
+func metricFamilyToJSON(mf *dto.MetricFamily) jsonMetricFamily {
+	name := mf.GetName()
+	family := jsonMetricFamily{
+		Name:    name,
+		Type:    strings.ToLower(mf.GetType().String()),
+		Help:    mf.GetHelp(),
+		Samples: []jsonSample{},
+	}
+	add := func(suffix string, labels map[string]string, value float64) {
+		family.Samples = append(family.Samples, jsonSample{
+			Name:   name + suffix,
+			Labels: labels,
+			Value:  formatJSONValue(value),
+		})
+	}
+
+	for _, m := range mf.GetMetric() {
+		labels := make(map[string]string, len(m.GetLabel()))
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		switch {
+		case m.Counter != nil:
+			add("", labels, m.GetCounter().GetValue())
+		case m.Gauge != nil:
+			add("", labels, m.GetGauge().GetValue())
+		case m.Untyped != nil:
+			add("", labels, m.GetUntyped().GetValue())
+		case m.Summary != nil:
+			for _, q := range m.GetSummary().GetQuantile() {
+				add("", withLabel(labels, "quantile", formatJSONValue(q.GetQuantile())), q.GetValue())
+			}
+			add("_sum", labels, m.GetSummary().GetSampleSum())
+			add("_count", labels, float64(m.GetSummary().GetSampleCount()))
+		case m.Histogram != nil:
+			infSeen := false
+			for _, b := range m.GetHistogram().GetBucket() {
+				if math.IsInf(b.GetUpperBound(), 1) {
+					infSeen = true
+				}
+				add("_bucket", withLabel(labels, "le", formatJSONValue(b.GetUpperBound())), float64(b.GetCumulativeCount()))
+			}
+			if !infSeen {
+				add("_bucket", withLabel(labels, "le", "+Inf"), float64(m.GetHistogram().GetSampleCount()))
+			}
+			add("_sum", labels, m.GetHistogram().GetSampleSum())
+			add("_count", labels, float64(m.GetHistogram().GetSampleCount()))
+		}
+	}
+	return family
+}
+
+// withLabel returns a copy of labels with the label name set to value.
+func withLabel(labels map[string]string, name, value string) map[string]string {
+	l := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		l[k] = v
+	}
+	l[name] = value
+	return l
+}
+
+func formatJSONValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
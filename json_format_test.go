@@ -0,0 +1,72 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestServeJSON(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "node_test_total", Help: "A counter."}, []string{"device"})
+	counter.WithLabelValues("sda").Add(3)
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "node_test_nan", Help: "A NaN gauge."})
+	gauge.Set(math.NaN())
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "node_test_seconds", Help: "A histogram.", Buckets: []float64{0.5}})
+	histogram.Observe(0.25)
+	histogram.Observe(2)
+	reg.MustRegister(counter, gauge, histogram)
+
+	h := &handler{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	rec := httptest.NewRecorder()
+	h.serveJSON(rec, reg)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("want content type application/json, got %q", ct)
+	}
+	var got []jsonMetricFamily
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []jsonMetricFamily{
+		{
+			Name: "node_test_nan", Type: "gauge", Help: "A NaN gauge.",
+			Samples: []jsonSample{{Name: "node_test_nan", Labels: map[string]string{}, Value: "NaN"}},
+		},
+		{
+			Name: "node_test_seconds", Type: "histogram", Help: "A histogram.",
+			Samples: []jsonSample{
+				{Name: "node_test_seconds_bucket", Labels: map[string]string{"le": "0.5"}, Value: "1"},
+				{Name: "node_test_seconds_bucket", Labels: map[string]string{"le": "+Inf"}, Value: "2"},
+				{Name: "node_test_seconds_sum", Labels: map[string]string{}, Value: "2.25"},
+				{Name: "node_test_seconds_count", Labels: map[string]string{}, Value: "2"},
+			},
+		},
+		{
+			Name: "node_test_total", Type: "counter", Help: "A counter.",
+			Samples: []jsonSample{{Name: "node_test_total", Labels: map[string]string{"device": "sda"}, Value: "3"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// nameFilteredGatherer drops the metric families whose name is not selected
+// by the include[] and exclude_name[] query parameters.
+type nameFilteredGatherer struct {
+	gatherer prometheus.Gatherer
+	include  []*regexp.Regexp
+	exclude  []*regexp.Regexp
+}
+
+// newNameFilteredGatherer wraps gatherer so that only metric families fully
+// matching one of the include patterns, if any, and none of the exclude
+// patterns are returned.
+func newNameFilteredGatherer(gatherer prometheus.Gatherer, include, exclude []string) (*nameFilteredGatherer, error) {
+	g := &nameFilteredGatherer{gatherer: gatherer}
+	var err error
+	if g.include, err = compileNamePatterns(include); err != nil {
+		return nil, err
+	}
+	if g.exclude, err = compileNamePatterns(exclude); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func compileNamePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		// Anchor the pattern like Prometheus does for label matchers.
+		re, err := regexp.Compile("^(?:" + p + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric name pattern %q: %w", p, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *nameFilteredGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := g.gatherer.Gather()
+	filtered := mfs[:0]
+	for _, mf := range mfs {
+		if g.selected(mf.GetName()) {
+			filtered = append(filtered, mf)
+		}
+	}
+	return filtered, err
+}
+
+func (g *nameFilteredGatherer) selected(name string) bool {
+	if len(g.include) > 0 && !matchesAny(g.include, name) {
+		return false
+	}
+	return !matchesAny(g.exclude, name)
+}
+
+func matchesAny(res []*regexp.Regexp, name string) bool {
+	for _, re := range res {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
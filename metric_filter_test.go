@@ -0,0 +1,79 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestNameFilteredGatherer(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	for _, name := range []string{"node_cpu_seconds_total", "node_load1", "node_load15", "node_memory_MemFree_bytes"} {
+		reg.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: "help"}))
+	}
+
+	tests := []struct {
+		name             string
+		include, exclude []string
+		want             []string
+	}{
+		{
+			name:    "include",
+			include: []string{"node_cpu_.*", "node_load1"},
+			want:    []string{"node_cpu_seconds_total", "node_load1"},
+		},
+		{
+			name:    "exclude",
+			exclude: []string{"node_load.*"},
+			want:    []string{"node_cpu_seconds_total", "node_memory_MemFree_bytes"},
+		},
+		{
+			name:    "include and exclude",
+			include: []string{"node_load.*"},
+			exclude: []string{"node_load15"},
+			want:    []string{"node_load1"},
+		},
+		{
+			name:    "anchored",
+			include: []string{"node_load"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := newNameFilteredGatherer(reg, test.include, test.exclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mfs, err := g.Gather()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, mf := range mfs {
+				got = append(got, mf.GetName())
+			}
+			if strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	if _, err := newNameFilteredGatherer(reg, []string{"node_("}, nil); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
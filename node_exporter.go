@@ -14,15 +14,24 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"log/slog"
 	"net/http"
-	_ "net/http/pprof"
+	"net/http/pprof"
 	"os"
+	"os/signal"
 	"os/user"
 	"runtime"
 	"slices"
 	"sort"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/prometheus/common/promslog"
 	"github.com/prometheus/common/promslog/flag"
@@ -43,46 +52,99 @@ import (
 // newHandler.
 type handler struct {
 	unfilteredHandler http.Handler
+	// unfilteredGatherer gathers the same metrics as unfilteredHandler
+	// exposes, for use outside of HTTP requests.
+	unfilteredGatherer prometheus.Gatherer
 	// enabledCollectors list is used for logging and filtering
 	enabledCollectors []string
 	// exporterMetricsRegistry is a separate registry for the metrics about
 	// the exporter itself.
 	exporterMetricsRegistry *prometheus.Registry
-	includeExporterMetrics  bool
-	maxRequests             int
-	logger                  *slog.Logger
+	// statusRegistry holds the metrics about the exporter which are exposed
+	// even with --web.disable-exporter-metrics, like remote write failures.
+	statusRegistry         *prometheus.Registry
+	includeExporterMetrics bool
+	scrapeMetrics          *scrapeMetrics
+	maxRequests            int
+	// inFlightSem limits the parallel scrapes to maxRequests across the
+	// unfiltered and all filtered handlers, nil if unlimited.
+	inFlightSem chan struct{}
+	// relabelRules are applied to all gathered metrics.
+	relabelRules []relabelRule
+	logger       *slog.Logger
 }
 
-func newHandler(includeExporterMetrics bool, maxRequests int, logger *slog.Logger) *handler {
+func newHandler(includeExporterMetrics bool, maxRequests int, relabelRules []relabelRule, logger *slog.Logger) *handler {
 	h := &handler{
 		exporterMetricsRegistry: prometheus.NewRegistry(),
+		statusRegistry:          prometheus.NewRegistry(),
 		includeExporterMetrics:  includeExporterMetrics,
+		scrapeMetrics:           newScrapeMetrics(),
 		maxRequests:             maxRequests,
+		relabelRules:            relabelRules,
 		logger:                  logger,
 	}
+	if maxRequests > 0 {
+		h.inFlightSem = make(chan struct{}, maxRequests)
+	}
 	if h.includeExporterMetrics {
 		h.exporterMetricsRegistry.MustRegister(
 			promcollectors.NewProcessCollector(promcollectors.ProcessCollectorOpts{}),
 			promcollectors.NewGoCollector(),
 		)
+		h.exporterMetricsRegistry.MustRegister(h.scrapeMetrics.collectors()...)
+	}
+	nc, err := collector.NewNodeCollector(logger)
+	if err != nil {
+		panic(fmt.Sprintf("Couldn't create metrics handler: couldn't create collector: %s", err))
+	}
+	h.logger.Info("Enabled collectors")
+	for n := range nc.Collectors {
+		h.enabledCollectors = append(h.enabledCollectors, n)
 	}
-	if innerHandler, err := h.innerHandler(); err != nil {
+	sort.Strings(h.enabledCollectors)
+	for _, c := range h.enabledCollectors {
+		h.logger.Info(c)
+	}
+	// The unfiltered gatherer and handler are only set here, ServeHTTP
+	// might run concurrently afterwards.
+	gatherer, err := h.gathererFor(nc)
+	if err != nil {
 		panic(fmt.Sprintf("Couldn't create metrics handler: %s", err))
-	} else {
-		h.unfilteredHandler = innerHandler
 	}
+	h.unfilteredGatherer = gatherer
+	h.unfilteredHandler = h.handlerFor(gatherer)
 	return h
 }
 
 // ServeHTTP implements http.Handler.
 func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.scrapeMetrics != nil {
+		defer h.scrapeMetrics.start()()
+	}
+
 	collects := r.URL.Query()["collect[]"]
 	h.logger.Debug("collect query:", "collects", collects)
 
 	excludes := r.URL.Query()["exclude[]"]
 	h.logger.Debug("exclude query:", "excludes", excludes)
 
-	if len(collects) == 0 && len(excludes) == 0 {
+	// Metric name filters. exclude[] already excludes collectors, so the
+	// counterpart of include[] is exclude_name[].
+	includeNames := r.URL.Query()["include[]"]
+	excludeNames := r.URL.Query()["exclude_name[]"]
+	h.logger.Debug("metric name query:", "include", includeNames, "exclude", excludeNames)
+
+	// The text formats are negotiated, JSON is only served on request.
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" {
+		h.logger.Debug("rejecting unsupported format", "format", format)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Unsupported format %q, only json is supported.", format)
+		return
+	}
+
+	if format == "" && len(collects) == 0 && len(excludes) == 0 && len(includeNames) == 0 && len(excludeNames) == 0 {
 		// No filters, use the prepared unfiltered handler.
 		h.unfilteredHandler.ServeHTTP(w, r)
 		return
@@ -95,8 +157,10 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	filters := &collects
-	if len(excludes) > 0 {
+	var filters *[]string
+	if len(collects) > 0 {
+		filters = &collects
+	} else if len(excludes) > 0 {
 		// In exclude mode, filtered collectors = enabled - excludeed.
 		f := []string{}
 		for _, c := range h.enabledCollectors {
@@ -108,57 +172,90 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// To serve filtered metrics, we create a filtering handler on the fly.
-	filteredHandler, err := h.innerHandler(*filters...)
-	if err != nil {
-		h.logger.Warn("Couldn't create filtered metrics handler:", "err", err)
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprintf(w, "Couldn't create filtered metrics handler: %s", err)
+	gatherer := h.unfilteredGatherer
+	if filters != nil {
+		var err error
+		gatherer, err = h.innerGatherer(*filters...)
+		if err != nil {
+			h.logger.Warn("Couldn't create filtered metrics handler:", "err", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Couldn't create filtered metrics handler: %s", err)
+			return
+		}
+	}
+	if len(includeNames) > 0 || len(excludeNames) > 0 {
+		nameFiltered, err := newNameFilteredGatherer(gatherer, includeNames, excludeNames)
+		if err != nil {
+			h.logger.Debug("rejecting metric name query", "err", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Couldn't create filtered metrics handler: %s", err)
+			return
+		}
+		gatherer = nameFiltered
+	}
+	if format == "json" {
+		h.limitInFlight(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.serveJSON(w, gatherer)
+		})).ServeHTTP(w, r)
 		return
 	}
-	filteredHandler.ServeHTTP(w, r)
+	h.handlerFor(gatherer).ServeHTTP(w, r)
 }
 
-// innerHandler is used to create both the one unfiltered http.Handler to be
-// wrapped by the outer handler and also the filtered handlers created on the
-// fly. The former is accomplished by calling innerHandler without any arguments
-// (in which case it will log all the collectors enabled via command-line
-// flags).
-func (h *handler) innerHandler(filters ...string) (http.Handler, error) {
+// innerGatherer returns a Gatherer for the given collectors. Filtering on
+// collectors which are all excluded yields no collector at all.
+func (h *handler) innerGatherer(filters ...string) (prometheus.Gatherer, error) {
+	if len(filters) == 0 {
+		return h.gathererFor(&collector.NodeCollector{})
+	}
 	nc, err := collector.NewNodeCollector(h.logger, filters...)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create collector: %s", err)
 	}
+	return h.gathererFor(nc)
+}
 
-	// Only log the creation of an unfiltered handler, which should happen
-	// only once upon startup.
-	if len(filters) == 0 {
-		h.logger.Info("Enabled collectors")
-		for n := range nc.Collectors {
-			h.enabledCollectors = append(h.enabledCollectors, n)
-		}
-		sort.Strings(h.enabledCollectors)
-		for _, c := range h.enabledCollectors {
-			h.logger.Info(c)
-		}
-	}
-
+// gathererFor returns a Gatherer for the metrics of nc and the exporter.
+func (h *handler) gathererFor(nc *collector.NodeCollector) (prometheus.Gatherer, error) {
 	r := prometheus.NewRegistry()
 	r.MustRegister(versioncollector.NewCollector("node_exporter"))
 	if err := r.Register(nc); err != nil {
 		return nil, fmt.Errorf("couldn't register node collector: %s", err)
 	}
 
+	var gatherers prometheus.Gatherers
+	if h.includeExporterMetrics {
+		gatherers = append(gatherers, h.exporterMetricsRegistry)
+	}
+	gatherers = append(gatherers, r)
+	if h.statusRegistry != nil {
+		gatherers = append(gatherers, h.statusRegistry)
+	}
+	var gatherer prometheus.Gatherer = gatherers
+	if len(h.relabelRules) > 0 {
+		gatherer = &relabelGatherer{gatherer: gatherer, rules: h.relabelRules, logger: h.logger}
+	}
+	return gatherer, nil
+}
+
+// handlerFor serves the metrics of gatherer, which was created by
+// gathererFor.
+func (h *handler) handlerFor(gatherer prometheus.Gatherer) http.Handler {
 	var handler http.Handler
 	if h.includeExporterMetrics {
 		handler = promhttp.HandlerFor(
-			prometheus.Gatherers{h.exporterMetricsRegistry, r},
+			gatherer,
 			promhttp.HandlerOpts{
-				ErrorLog:            slog.NewLogLogger(h.logger.Handler(), slog.LevelError),
-				ErrorHandling:       promhttp.ContinueOnError,
-				MaxRequestsInFlight: h.maxRequests,
-				Registry:            h.exporterMetricsRegistry,
+				ErrorLog:      slog.NewLogLogger(h.logger.Handler(), slog.LevelError),
+				ErrorHandling: promhttp.ContinueOnError,
+				// The classic text format stays the default, OpenMetrics
+				// is only served to clients asking for it.
+				EnableOpenMetrics:                   true,
+				EnableOpenMetricsTextCreatedSamples: true,
+				Registry:                            h.exporterMetricsRegistry,
 			},
 		)
+		handler = h.limitInFlight(handler)
 		// Note that we have to use h.exporterMetricsRegistry here to
 		// use the same promhttp metrics for all expositions.
 		handler = promhttp.InstrumentMetricHandler(
@@ -166,16 +263,157 @@ func (h *handler) innerHandler(filters ...string) (http.Handler, error) {
 		)
 	} else {
 		handler = promhttp.HandlerFor(
-			r,
+			gatherer,
 			promhttp.HandlerOpts{
-				ErrorLog:            slog.NewLogLogger(h.logger.Handler(), slog.LevelError),
-				ErrorHandling:       promhttp.ContinueOnError,
-				MaxRequestsInFlight: h.maxRequests,
+				ErrorLog:      slog.NewLogLogger(h.logger.Handler(), slog.LevelError),
+				ErrorHandling: promhttp.ContinueOnError,
+				// The classic text format stays the default, OpenMetrics
+				// is only served to clients asking for it.
+				EnableOpenMetrics:                   true,
+				EnableOpenMetricsTextCreatedSamples: true,
 			},
 		)
+		handler = h.limitInFlight(handler)
 	}
 
-	return handler, nil
+	return handler
+}
+
+// limitInFlight rejects requests to next while maxRequests scrapes are in
+// flight, like promhttp's MaxRequestsInFlight but shared by all handlers.
+func (h *handler) limitInFlight(next http.Handler) http.Handler {
+	if h.inFlightSem == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case h.inFlightSem <- struct{}{}:
+			defer func() { <-h.inFlightSem }()
+		default:
+			http.Error(w, fmt.Sprintf(
+				"Limit of concurrent requests reached (%d), try again later.", h.maxRequests,
+			), http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// landingPageExtraHTML renders the extra links and the runtime information
+// shown below the links on the landing page. The extra links are rendered
+// here as the links of the landing page are always relative to it.
+func landingPageExtraHTML(links []web.LandingLinks, enabledCollectors []string) string {
+	var b strings.Builder
+	if len(links) > 0 {
+		b.WriteString("<h2>Links</h2>\n<ul>\n")
+		for _, l := range links {
+			fmt.Fprintf(&b, "<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(l.Address), html.EscapeString(l.Text))
+		}
+		b.WriteString("</ul>\n")
+	}
+	b.WriteString("<h2>Runtime information</h2>\n<ul>\n")
+	fmt.Fprintf(&b, "<li>Build context: %s</li>\n", html.EscapeString(version.BuildContext()))
+	fmt.Fprintf(&b, "<li>procfs path: <code>%s</code></li>\n", html.EscapeString(collector.ProcPath()))
+	fmt.Fprintf(&b, "<li>sysfs path: <code>%s</code></li>\n", html.EscapeString(collector.SysPath()))
+	fmt.Fprintf(&b, "<li>rootfs path: <code>%s</code></li>\n", html.EscapeString(collector.RootfsPath()))
+	b.WriteString("</ul>\n")
+	fmt.Fprintf(&b, "<h2>Enabled collectors (%d)</h2>\n<ul>\n", len(enabledCollectors))
+	for _, c := range enabledCollectors {
+		fmt.Fprintf(&b, "<li>%s</li>\n", html.EscapeString(c))
+	}
+	b.WriteString("</ul>\n")
+	return b.String()
+}
+
+// parseLandingPageLinks parses extra landing page links, each in the form
+// "<text>=<address>".
+func parseLandingPageLinks(links []string) ([]web.LandingLinks, error) {
+	var result []web.LandingLinks
+	for _, link := range links {
+		text, address, ok := strings.Cut(link, "=")
+		if !ok || text == "" || address == "" {
+			return nil, fmt.Errorf("expected <text>=<address>, got %q", link)
+		}
+		result = append(result, web.LandingLinks{Address: address, Text: text})
+	}
+	return result, nil
+}
+
+// setCollectorCacheTTLs sets the cache TTL of every collector listed in
+// overrides, each in the form "<collector>:<duration>".
+func setCollectorCacheTTLs(overrides []string) error {
+	for _, override := range overrides {
+		name, d, ok := strings.Cut(override, ":")
+		if !ok {
+			return fmt.Errorf("expected <collector>:<duration>, got %q", override)
+		}
+		ttl, err := time.ParseDuration(d)
+		if err != nil {
+			return err
+		}
+		if err := collector.SetCollectorCacheTTL(name, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setCollectorLogLevels gives every collector listed in overrides, each in
+// the form "<collector>:<level>", its own logger using the global log format.
+func setCollectorLogLevels(config *promslog.Config, overrides []string) error {
+	for _, override := range overrides {
+		name, lvl, ok := strings.Cut(override, ":")
+		if !ok {
+			return fmt.Errorf("expected <collector>:<level>, got %q", override)
+		}
+		level := promslog.NewLevel()
+		if err := level.Set(lvl); err != nil {
+			return err
+		}
+		logger := promslog.New(&promslog.Config{
+			Level:  level,
+			Format: config.Format,
+			Style:  config.Style,
+			Writer: config.Writer,
+		})
+		if err := collector.SetCollectorLogger(name, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readyHandler serves /-/ready, which fails once shuttingDown is set.
+func readyHandler(shuttingDown *atomic.Bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if shuttingDown.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, "Shutting down")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "Ready")
+	}
+}
+
+// drainServer fails readiness right away and keeps serving for delay, so that
+// load balancers see /-/ready fail and stop sending scrapes. Then it stops
+// listening and waits up to timeout for in-flight requests to finish. Requests
+// still running after timeout are cut off, which is not an error.
+func drainServer(server *http.Server, shuttingDown *atomic.Bool, delay, timeout time.Duration, logger *slog.Logger) error {
+	shuttingDown.Store(true)
+	if delay > 0 {
+		logger.Info("Failing readiness before shutting down", "delay", delay)
+		time.Sleep(delay)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		logger.Warn("Shutdown timeout expired, closing remaining connections", "timeout", timeout, "err", err)
+		return server.Close()
+	}
+	return err
 }
 
 func main() {
@@ -184,10 +422,30 @@ func main() {
 			"web.telemetry-path",
 			"Path under which to expose metrics.",
 		).Default("/metrics").String()
+		collectorsPath = kingpin.Flag(
+			"web.collectors-path",
+			"Path under which to list the collectors and their last scrape as JSON. Set to an empty string to disable.",
+		).Default("/collectors").String()
+		telemetryTitle = kingpin.Flag(
+			"web.telemetry-title",
+			"Title of the landing page.",
+		).Default("Node Exporter").String()
+		disableLandingPage = kingpin.Flag(
+			"web.disable-landing-page",
+			"Don't serve the landing page.",
+		).Bool()
+		landingPageLinks = kingpin.Flag(
+			"web.landing-page-link",
+			"Extra link on the landing page, e.g. Runbook=https://example.com/runbook. (repeatable)",
+		).Strings()
 		disableExporterMetrics = kingpin.Flag(
 			"web.disable-exporter-metrics",
 			"Exclude metrics about the exporter itself (promhttp_*, process_*, go_*).",
 		).Bool()
+		relabelConfigFile = kingpin.Flag(
+			"collector.relabel-config",
+			"Path to a YAML file with label renaming rules applied to the exposed metrics.",
+		).String()
 		maxRequests = kingpin.Flag(
 			"web.max-requests",
 			"Maximum number of parallel scrape requests. Use 0 to disable.",
@@ -196,9 +454,45 @@ func main() {
 			"collector.disable-defaults",
 			"Set all collectors to disabled by default.",
 		).Default("false").Bool()
+		selfTest = kingpin.Flag(
+			"collector.selftest",
+			"Run every enabled collector once, print which ones failed and exit without serving metrics.",
+		).Default("false").Bool()
+		enablePprof = kingpin.Flag(
+			"web.enable-pprof",
+			"Expose the Go runtime profiling endpoints under /debug/pprof/.",
+		).Default("false").Bool()
+		remoteWriteURL = kingpin.Flag(
+			"agent.remote-write-url",
+			"Push metrics to this Prometheus remote write endpoint in addition to serving them. Disabled if empty.",
+		).String()
+		remoteWriteInterval = kingpin.Flag(
+			"agent.interval",
+			"Interval at which metrics are pushed to the remote write endpoint.",
+		).Default("15s").Duration()
+		remoteWriteBearerTokenFile = kingpin.Flag(
+			"agent.bearer-token-file",
+			"File containing a bearer token sent with remote write requests.",
+		).String()
+		shutdownTimeout = kingpin.Flag(
+			"web.shutdown-timeout",
+			"Maximum time to wait for in-flight requests to finish on shutdown.",
+		).Default("10s").Duration()
+		shutdownDelay = kingpin.Flag(
+			"web.shutdown-delay",
+			"Time to keep serving with /-/ready failing on shutdown, for load balancers to take the exporter out of rotation.",
+		).Default("0s").Duration()
 		maxProcs = kingpin.Flag(
 			"runtime.gomaxprocs", "The target number of CPUs Go will run on (GOMAXPROCS)",
 		).Envar("GOMAXPROCS").Default("1").Int()
+		collectorLogLevels = kingpin.Flag(
+			"log.level.collector",
+			"Override the log level of a single collector, e.g. systemd:debug. (repeatable)",
+		).PlaceHolder("<collector>:<level>").Strings()
+		collectorCacheTTLs = kingpin.Flag(
+			"collector.cache-ttl",
+			"Serve the metrics of a single collector from cache for the given duration after a successful run, e.g. hwmon:1m. (repeatable)",
+		).PlaceHolder("<collector>:<duration>").Strings()
 		toolkitFlags = kingpinflag.AddFlags(kingpin.CommandLine, ":9100")
 	)
 
@@ -207,8 +501,18 @@ func main() {
 	kingpin.Version(version.Print("node_exporter"))
 	kingpin.CommandLine.UsageWriter(os.Stdout)
 	kingpin.HelpFlag.Short('h')
-	kingpin.Parse()
+	args, err := collector.ExpandCollectorConfig(os.Args[1:])
+	kingpin.FatalIfError(err, "")
+	kingpin.MustParse(kingpin.CommandLine.Parse(args))
 	logger := promslog.New(promslogConfig)
+	if err := setCollectorLogLevels(promslogConfig, *collectorLogLevels); err != nil {
+		logger.Error("Invalid collector log level", "err", err)
+		os.Exit(1)
+	}
+	if err := setCollectorCacheTTLs(*collectorCacheTTLs); err != nil {
+		logger.Error("Invalid collector cache TTL", "err", err)
+		os.Exit(1)
+	}
 
 	if *disableDefaultCollectors {
 		collector.DisableDefaultCollectors()
@@ -221,10 +525,70 @@ func main() {
 	runtime.GOMAXPROCS(*maxProcs)
 	logger.Debug("Go MAXPROCS", "procs", runtime.GOMAXPROCS(0))
 
-	http.Handle(*metricsPath, newHandler(!*disableExporterMetrics, *maxRequests, logger))
-	if *metricsPath != "/" {
+	if *selfTest {
+		if !runSelfTest(os.Stdout, logger) {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Use a dedicated mux instead of http.DefaultServeMux, on which
+	// net/http/pprof registers its handlers unconditionally.
+	mux := http.NewServeMux()
+	stopRemoteWriter := func() {}
+	var relabelRules []relabelRule
+	if *relabelConfigFile != "" {
+		relabelRules, err = loadRelabelConfig(*relabelConfigFile)
+		if err != nil {
+			logger.Error("Error loading relabel config", "file", *relabelConfigFile, "err", err)
+			os.Exit(1)
+		}
+	}
+	metricsHandler := newHandler(!*disableExporterMetrics, *maxRequests, relabelRules, logger)
+	mux.Handle(*metricsPath, metricsHandler)
+	if *remoteWriteURL != "" {
+		writer, err := newRemoteWriter(*remoteWriteURL, *remoteWriteInterval, *remoteWriteBearerTokenFile, metricsHandler.unfilteredGatherer, logger)
+		if err != nil {
+			logger.Error("Error creating remote writer", "err", err)
+			os.Exit(1)
+		}
+		metricsHandler.statusRegistry.MustRegister(writer.failures)
+		logger.Info("Pushing metrics via remote write", "url", *remoteWriteURL, "interval", *remoteWriteInterval)
+		writerCtx, cancel := context.WithCancel(context.Background())
+		writerDone := make(chan struct{})
+		go func() {
+			defer close(writerDone)
+			writer.run(writerCtx)
+		}()
+		stopRemoteWriter = func() {
+			cancel()
+			<-writerDone
+		}
+	}
+	mux.HandleFunc("/-/healthy", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "Healthy")
+	})
+	var shuttingDown atomic.Bool
+	mux.HandleFunc("/-/ready", readyHandler(&shuttingDown))
+	if *collectorsPath != "" {
+		mux.HandleFunc(*collectorsPath, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(collector.CollectorStatuses()); err != nil {
+				logger.Warn("Error writing collector statuses", "err", err)
+			}
+		})
+	}
+	if *metricsPath != "/" && !*disableLandingPage {
+		extraLinks, err := parseLandingPageLinks(*landingPageLinks)
+		if err != nil {
+			logger.Error("Couldn't parse landing page links", "err", err)
+			os.Exit(1)
+		}
 		landingConfig := web.LandingConfig{
-			Name:        "Node Exporter",
+			Name:        *telemetryTitle,
 			Description: "Prometheus Node Exporter",
 			Version:     version.Info(),
 			Links: []web.LandingLinks{
@@ -232,19 +596,63 @@ func main() {
 					Address: *metricsPath,
 					Text:    "Metrics",
 				},
+				{
+					Address: "/-/healthy",
+					Text:    "Health",
+				},
+				{
+					Address: "/-/ready",
+					Text:    "Readiness",
+				},
 			},
+			ExtraHTML: landingPageExtraHTML(extraLinks, metricsHandler.enabledCollectors),
+		}
+		if *collectorsPath != "" {
+			landingConfig.Links = append(landingConfig.Links, web.LandingLinks{
+				Address: *collectorsPath,
+				Text:    "Collectors",
+			})
 		}
 		landingPage, err := web.NewLandingPage(landingConfig)
 		if err != nil {
 			logger.Error(err.Error())
 			os.Exit(1)
 		}
-		http.Handle("/", landingPage)
+		mux.Handle("/", landingPage)
+	}
+	if *enablePprof {
+		logger.Info("Profiling endpoints enabled", "path", "/debug/pprof/")
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 	}
 
-	server := &http.Server{}
-	if err := web.ListenAndServe(server, toolkitFlags, logger); err != nil {
+	server := &http.Server{Handler: mux}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- web.ListenAndServe(server, toolkitFlags, logger)
+	}()
+
+	term := make(chan os.Signal, 1)
+	signal.Notify(term, syscall.SIGTERM, os.Interrupt)
+	select {
+	case err := <-serveErr:
+		logger.Error(err.Error())
+		os.Exit(1)
+	case sig := <-term:
+		logger.Info("Received signal, shutting down", "signal", sig, "delay", *shutdownDelay, "timeout", *shutdownTimeout)
+	}
+
+	stopRemoteWriter()
+	if err := drainServer(server, &shuttingDown, *shutdownDelay, *shutdownTimeout, logger); err != nil {
+		logger.Error("Error shutting down HTTP server", "err", err)
+		os.Exit(1)
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logger.Error(err.Error())
 		os.Exit(1)
 	}
+	logger.Info("Shutdown complete")
 }
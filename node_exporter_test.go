@@ -14,15 +14,29 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/promslog"
+	"github.com/prometheus/exporter-toolkit/web"
+	"github.com/prometheus/node_exporter/collector"
 	"github.com/prometheus/procfs"
 )
 
@@ -152,3 +166,282 @@ func runCommandAndTests(cmd *exec.Cmd, address string, fn func(pid int) error) e
 	}
 	return err
 }
+
+func TestParseLandingPageLinks(t *testing.T) {
+	links, err := parseLandingPageLinks([]string{"Runbook=https://example.com/runbook?q=a", "Logs=/logs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []web.LandingLinks{
+		{Address: "https://example.com/runbook?q=a", Text: "Runbook"},
+		{Address: "/logs", Text: "Logs"},
+	}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("want links %v, got %v", want, links)
+	}
+
+	for _, link := range []string{"https://example.com", "=/logs", "Logs="} {
+		if _, err := parseLandingPageLinks([]string{link}); err == nil {
+			t.Errorf("want error for link %q", link)
+		}
+	}
+}
+
+// blockingGatherer blocks in Gather until release is closed.
+type blockingGatherer struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (g blockingGatherer) Gather() ([]*dto.MetricFamily, error) {
+	g.entered <- struct{}{}
+	<-g.release
+	return nil, nil
+}
+
+func TestHandlerMaxRequests(t *testing.T) {
+	g := blockingGatherer{entered: make(chan struct{}), release: make(chan struct{})}
+	h := &handler{
+		unfilteredGatherer: g,
+		maxRequests:        1,
+		inFlightSem:        make(chan struct{}, 1),
+		logger:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	h.unfilteredHandler = h.handlerFor(g)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/metrics", nil))
+	}()
+	<-g.entered
+
+	// The unfiltered scrape in flight counts against the limit of the
+	// filtered ones.
+	for _, target := range []string{
+		"/metrics",
+		"/metrics?include[]=node_cpu_seconds_total",
+		"/metrics?format=json",
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s: want status %d, got %d", target, http.StatusServiceUnavailable, rec.Code)
+		}
+	}
+
+	close(g.release)
+	<-done
+}
+
+func TestHandlerOpenMetrics(t *testing.T) {
+	r := prometheus.NewRegistry()
+	r.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "test_events_total", Help: "Test counter."}))
+	h := &handler{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	h.unfilteredHandler = h.handlerFor(r)
+
+	for _, test := range []struct {
+		accept      string
+		contentType string
+		openMetrics bool
+	}{
+		// The classic text format stays the default.
+		{accept: "", contentType: "text/plain", openMetrics: false},
+		{accept: "application/openmetrics-text;version=1.0.0", contentType: "application/openmetrics-text", openMetrics: true},
+	} {
+		req := httptest.NewRequest("GET", "/metrics", nil)
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, test.contentType) {
+			t.Errorf("Accept %q: want content type %s, got %s", test.accept, test.contentType, got)
+		}
+		body := rec.Body.String()
+		if got := strings.HasSuffix(body, "# EOF\n"); got != test.openMetrics {
+			t.Errorf("Accept %q: want EOF terminator %t, got:\n%s", test.accept, test.openMetrics, body)
+		}
+		if got := strings.Contains(body, "test_events_created "); got != test.openMetrics {
+			t.Errorf("Accept %q: want created sample %t, got:\n%s", test.accept, test.openMetrics, body)
+		}
+	}
+}
+
+func TestHandlerStatusMetricsWithoutExporterMetrics(t *testing.T) {
+	h := &handler{
+		exporterMetricsRegistry: prometheus.NewRegistry(),
+		statusRegistry:          prometheus.NewRegistry(),
+		includeExporterMetrics:  false,
+		logger:                  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	w, err := newRemoteWriter("http://localhost", time.Second, "", nil, h.logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.statusRegistry.MustRegister(w.failures)
+	h.exporterMetricsRegistry.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_exporter_metric", Help: "Test gauge."}))
+
+	gatherer, err := h.gathererFor(&collector.NodeCollector{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfs, err := gatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]bool{}
+	for _, mf := range mfs {
+		names[mf.GetName()] = true
+	}
+	if !names["node_exporter_remote_write_failures_total"] {
+		t.Error("remote write failures missing with exporter metrics disabled")
+	}
+	if names["test_exporter_metric"] {
+		t.Error("exporter metrics exposed although they are disabled")
+	}
+}
+
+func TestSetCollectorLogLevels(t *testing.T) {
+	if _, err := kingpin.CommandLine.Parse([]string{}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	config := &promslog.Config{Level: promslog.NewLevel(), Writer: &buf}
+	for _, overrides := range [][]string{
+		{"time"},
+		{"time:verbose"},
+		{"doesnotexist:debug"},
+	} {
+		if err := setCollectorLogLevels(config, overrides); err == nil {
+			t.Errorf("%v: want error", overrides)
+		}
+	}
+
+	prev := collector.CollectorLoggerOverride("time")
+	t.Cleanup(func() { collector.SetCollectorLogger("time", prev) })
+	if err := setCollectorLogLevels(config, []string{"time:debug"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The log lines of the framework about the collector use its level too,
+	// while the global logger only logs errors.
+	level := promslog.NewLevel()
+	if err := level.Set("error"); err != nil {
+		t.Fatal(err)
+	}
+	global := promslog.New(&promslog.Config{Level: level, Writer: io.Discard})
+	nc, err := collector.NewNodeCollector(global, "time")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := prometheus.NewRegistry()
+	r.MustRegister(nc)
+	if _, err := r.Gather(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "collector succeeded") {
+		t.Errorf("want debug log of the time collector, got:\n%s", buf.String())
+	}
+}
+
+func TestHandlerExcludeAllCollectors(t *testing.T) {
+	h := &handler{
+		enabledCollectors: []string{"cpu"},
+		logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics?exclude[]=cpu", nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("want status %d, got %d", http.StatusOK, rec.Code)
+			}
+			if strings.Contains(rec.Body.String(), "node_scrape_collector_success") {
+				t.Errorf("expected no collector metrics, got:\n%s", rec.Body.String())
+			}
+		}()
+	}
+	wg.Wait()
+	if h.unfilteredGatherer != nil {
+		t.Error("filtered request set the unfiltered gatherer")
+	}
+}
+
+func TestDrainServer(t *testing.T) {
+	var shuttingDown atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/-/ready", readyHandler(&shuttingDown))
+	server := &http.Server{Handler: mux}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(ln)
+	url := "http://" + ln.Addr().String() + "/-/ready"
+
+	ready := func() (int, error) {
+		resp, err := http.Get(url)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+	if code, err := ready(); err != nil || code != http.StatusOK {
+		t.Fatalf("want ready before shutdown, got %d, %v", code, err)
+	}
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- drainServer(server, &shuttingDown, 200*time.Millisecond, time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}()
+
+	// During the delay the server is still listening, but not ready.
+	for !shuttingDown.Load() {
+		time.Sleep(time.Millisecond)
+	}
+	if code, err := ready(); err != nil || code != http.StatusServiceUnavailable {
+		t.Fatalf("want status %d during the shutdown delay, got %d, %v", http.StatusServiceUnavailable, code, err)
+	}
+
+	if err := <-drained; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ready(); err == nil {
+		t.Error("expected the server to stop listening after the delay")
+	}
+}
+
+func TestDrainServerTimeout(t *testing.T) {
+	var shuttingDown atomic.Bool
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	server := &http.Server{Handler: mux}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(ln)
+	go func() {
+		if resp, err := http.Get("http://" + ln.Addr().String() + "/slow"); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	// A request outliving the timeout is cut off without failing the shutdown.
+	if err := drainServer(server, &shuttingDown, 0, 50*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil))); err != nil {
+		t.Fatalf("want no error when the shutdown timeout expires, got %v", err)
+	}
+}
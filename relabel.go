@@ -0,0 +1,146 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
+)
+
+// relabelConfig is the content of the file passed to --collector.relabel-config.
+//
+//	rules:
+//	  - metrics: node_cpu_.*
+//	    rename:
+//	      cpu: core
+type relabelConfig struct {
+	Rules []relabelRule `yaml:"rules"`
+}
+
+// relabelRule renames labels of the metric families whose name fully matches
+// Metrics.
+type relabelRule struct {
+	Metrics string            `yaml:"metrics"`
+	Rename  map[string]string `yaml:"rename"`
+
+	re *regexp.Regexp
+}
+
+func loadRelabelConfig(path string) ([]relabelRule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read relabel config: %w", err)
+	}
+	return parseRelabelConfig(content)
+}
+
+func parseRelabelConfig(content []byte) ([]relabelRule, error) {
+	cfg := &relabelConfig{}
+	if err := yaml.UnmarshalStrict(content, cfg); err != nil {
+		return nil, err
+	}
+	rules := cfg.Rules
+	for i := range rules {
+		r := &rules[i]
+		re, err := regexp.Compile("^(?:" + r.Metrics + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid metrics pattern %q: %w", r.Metrics, err)
+		}
+		r.re = re
+		if len(r.Rename) == 0 {
+			return nil, fmt.Errorf("rule for %q has no labels to rename", r.Metrics)
+		}
+		for from, to := range r.Rename {
+			for _, name := range []string{from, to} {
+				if !model.LabelName(name).IsValidLegacy() || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+					return nil, fmt.Errorf("invalid label name %q in rule for %q", name, r.Metrics)
+				}
+			}
+		}
+	}
+	return rules, nil
+}
+
+// relabelGatherer applies relabel rules to the metric families of gatherer.
+type relabelGatherer struct {
+	gatherer prometheus.Gatherer
+	rules    []relabelRule
+	logger   *slog.Logger
+}
+
+// Gather implements prometheus.Gatherer.
+func (g *relabelGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := g.gatherer.Gather()
+	for _, mf := range mfs {
+		for _, rule := range g.rules {
+			if !rule.re.MatchString(mf.GetName()) {
+				continue
+			}
+			g.relabel(mf, rule.Rename)
+		}
+	}
+	return mfs, err
+}
+
+// relabel renames the labels of all metrics of mf in place, all at once so
+// that labels can be swapped. If a metric would end up with the same label
+// twice, e.g. as it already has a label with a new name, or two metrics with
+// the same labels, the family is left alone so that it keeps one consistent
+// label schema.
+func (g *relabelGatherer) relabel(mf *dto.MetricFamily, rename map[string]string) {
+	relabeled := make([][]*dto.LabelPair, len(mf.GetMetric()))
+	seen := make(map[string]bool, len(mf.GetMetric()))
+	for i, m := range mf.GetMetric() {
+		labels := make([]*dto.LabelPair, 0, len(m.GetLabel()))
+		names := make(map[string]bool, len(m.GetLabel()))
+		for _, l := range m.GetLabel() {
+			name := l.GetName()
+			if to, ok := rename[name]; ok {
+				name = to
+			}
+			if names[name] {
+				g.logger.Warn("Not renaming labels, metric would have a label twice", "metric", mf.GetName(), "label", name)
+				return
+			}
+			names[name] = true
+			labels = append(labels, &dto.LabelPair{Name: proto.String(name), Value: proto.String(l.GetValue())})
+		}
+		// Label pairs have to stay sorted by name.
+		sort.Slice(labels, func(i, j int) bool { return labels[i].GetName() < labels[j].GetName() })
+
+		var key strings.Builder
+		for _, l := range labels {
+			key.WriteString(l.GetName() + "\xff" + l.GetValue() + "\xff")
+		}
+		if seen[key.String()] {
+			g.logger.Warn("Not renaming labels, metrics would have the same labels", "metric", mf.GetName())
+			return
+		}
+		seen[key.String()] = true
+		relabeled[i] = labels
+	}
+	for i, m := range mf.GetMetric() {
+		m.Label = relabeled[i]
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseRelabelConfig(t *testing.T) {
+	for _, config := range []string{
+		"rules: [{metrics: '(', rename: {cpu: core}}]",
+		"rules: [{metrics: node_cpu_.*}]",
+		"rules: [{metrics: node_cpu_.*, rename: {cpu: __name__}}]",
+		"rules: [{metrics: node_cpu_.*, rename: {cpu: 'a-b'}}]",
+		"rule: []",
+	} {
+		if _, err := parseRelabelConfig([]byte(config)); err == nil {
+			t.Errorf("expected error for %q", config)
+		}
+	}
+}
+
+func TestRelabelGatherer(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	cpu := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "node_cpu_info", Help: "help"}, []string{"cpu", "vendor"})
+	cpu.WithLabelValues("0", "intel").Set(1)
+	guest := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "node_cpu_guest", Help: "help"}, []string{"core", "cpu"})
+	guest.WithLabelValues("1", "0").Set(2)
+	softnet := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "node_softnet_dropped", Help: "help"}, []string{"cpu"})
+	softnet.WithLabelValues("0").Set(3)
+	reg.MustRegister(cpu, guest, softnet)
+
+	rules, err := parseRelabelConfig([]byte(`
+rules:
+  - metrics: node_cpu_.*
+    rename:
+      core: cpu
+      cpu: a_core
+      vendor: manufacturer
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &relabelGatherer{gatherer: reg, rules: rules, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	// The labels are renamed at once, so core takes the place of cpu on
+	// node_cpu_guest. node_softnet_dropped is not matched by the rule.
+	want := `# HELP node_cpu_guest help
+# TYPE node_cpu_guest gauge
+node_cpu_guest{a_core="0",cpu="1"} 2
+# HELP node_cpu_info help
+# TYPE node_cpu_info gauge
+node_cpu_info{a_core="0",manufacturer="intel"} 1
+# HELP node_softnet_dropped help
+# TYPE node_softnet_dropped gauge
+node_softnet_dropped{cpu="0"} 3
+`
+	if err := testutil.GatherAndCompare(g, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// staticGatherer returns fixed metric families.
+type staticGatherer []*dto.MetricFamily
+
+func (g staticGatherer) Gather() ([]*dto.MetricFamily, error) {
+	return g, nil
+}
+
+func TestRelabelGathererConflicts(t *testing.T) {
+	gauge := func(value float64, labels ...string) *dto.Metric {
+		m := &dto.Metric{Gauge: &dto.Gauge{Value: proto.Float64(value)}}
+		for i := 0; i < len(labels); i += 2 {
+			m.Label = append(m.Label, &dto.LabelPair{Name: proto.String(labels[i]), Value: proto.String(labels[i+1])})
+		}
+		return m
+	}
+	family := func(name string, metrics ...*dto.Metric) *dto.MetricFamily {
+		return &dto.MetricFamily{Name: proto.String(name), Help: proto.String("help"), Type: dto.MetricType_GAUGE.Enum(), Metric: metrics}
+	}
+	g := &relabelGatherer{
+		gatherer: staticGatherer{
+			// After renaming core, both metrics would be node_collide{cpu="0"}.
+			family("node_collide", gauge(1, "core", "0"), gauge(2, "cpu", "0")),
+			family("node_renamed", gauge(4, "core", "1", "mode", "idle"), gauge(5, "core", "2", "mode", "idle")),
+			// The metric would have a cpu label twice.
+			family("node_twice", gauge(3, "core", "1", "cpu", "0")),
+		},
+		rules:  []relabelRule{{re: regexp.MustCompile("^node_.*$"), Rename: map[string]string{"core": "cpu"}}},
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	// Families with conflicts keep their labels, the others are renamed.
+	want := `# HELP node_collide help
+# TYPE node_collide gauge
+node_collide{core="0"} 1
+node_collide{cpu="0"} 2
+# HELP node_renamed help
+# TYPE node_renamed gauge
+node_renamed{cpu="1",mode="idle"} 4
+node_renamed{cpu="2",mode="idle"} 5
+# HELP node_twice help
+# TYPE node_twice gauge
+node_twice{core="1",cpu="0"} 3
+`
+	if err := testutil.GatherAndCompare(g, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
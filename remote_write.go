@@ -0,0 +1,231 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/version"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// remoteWriter periodically gathers metrics and pushes them to a Prometheus
+// remote write endpoint.
+type remoteWriter struct {
+	url         string
+	interval    time.Duration
+	bearerToken string
+	gatherer    prometheus.Gatherer
+	client      *http.Client
+	failures    prometheus.Counter
+	logger      *slog.Logger
+}
+
+func newRemoteWriter(url string, interval time.Duration, bearerTokenFile string, gatherer prometheus.Gatherer, logger *slog.Logger) (*remoteWriter, error) {
+	w := &remoteWriter{
+		url:      url,
+		interval: interval,
+		gatherer: gatherer,
+		client:   &http.Client{Timeout: interval},
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "node_exporter_remote_write_failures_total",
+			Help: "Total number of remote write requests which failed after all retries.",
+		}),
+		logger: logger,
+	}
+	if bearerTokenFile != "" {
+		token, err := os.ReadFile(bearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bearer token file: %w", err)
+		}
+		w.bearerToken = strings.TrimSpace(string(token))
+	}
+	return w, nil
+}
+
+// run pushes metrics every interval until ctx is canceled.
+func (w *remoteWriter) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.push(ctx); err != nil {
+			w.failures.Inc()
+			w.logger.Error("Remote write failed", "url", w.url, "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// push sends a single snapshot of all metrics, retrying recoverable errors
+// with exponential backoff until the next interval is due.
+func (w *remoteWriter) push(ctx context.Context) error {
+	mfs, err := w.gatherer.Gather()
+	if err != nil {
+		// Like the HTTP handler, continue with whatever could be gathered.
+		w.logger.Warn("Error gathering metrics for remote write", "err", err)
+	}
+	body := snappy.Encode(nil, encodeWriteRequest(mfs, time.Now()))
+
+	ctx, cancel := context.WithTimeout(ctx, w.interval)
+	defer cancel()
+	b := &backoff.Backoff{Min: 100 * time.Millisecond, Max: w.interval / 2, Jitter: true}
+	for {
+		retry, err := w.send(ctx, body)
+		if err == nil || !retry {
+			return err
+		}
+		d := b.Duration()
+		w.logger.Debug("Retrying remote write", "err", err, "backoff", d)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(d):
+		}
+	}
+}
+
+// send posts body to the remote write endpoint and reports whether a failed
+// request may be retried.
+func (w *remoteWriter) send(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "node_exporter/"+version.Version)
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if w.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+w.bearerToken)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+	err = fmt.Errorf("server returned HTTP status %s: %s", resp.Status, bytes.TrimSpace(msg))
+	// Client errors other than rate limiting won't go away on retry.
+	return resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+type remoteWriteLabel struct {
+	name, value string
+}
+
+// encodeWriteRequest converts the gathered metric families into a
+// prometheus.WriteRequest protobuf message. Samples without their own
+// timestamp are stamped with now.
+func encodeWriteRequest(mfs []*dto.MetricFamily, now time.Time) []byte {
+	var buf []byte
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			ts := now.UnixMilli()
+			if m.TimestampMs != nil {
+				ts = m.GetTimestampMs()
+			}
+			labels := make([]remoteWriteLabel, 0, len(m.GetLabel())+1)
+			for _, l := range m.GetLabel() {
+				labels = append(labels, remoteWriteLabel{l.GetName(), l.GetValue()})
+			}
+			add := func(suffix string, value float64, extra ...remoteWriteLabel) {
+				buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+				buf = protowire.AppendBytes(buf, encodeTimeSeries(mf.GetName()+suffix, labels, extra, value, ts))
+			}
+
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				add("", m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add("", m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add("", m.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				for _, q := range m.GetSummary().GetQuantile() {
+					add("", q.GetValue(), remoteWriteLabel{"quantile", strconv.FormatFloat(q.GetQuantile(), 'g', -1, 64)})
+				}
+				add("_sum", m.GetSummary().GetSampleSum())
+				add("_count", float64(m.GetSummary().GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				infSeen := false
+				for _, b := range m.GetHistogram().GetBucket() {
+					if math.IsInf(b.GetUpperBound(), 1) {
+						infSeen = true
+					}
+					add("_bucket", float64(b.GetCumulativeCount()), remoteWriteLabel{"le", strconv.FormatFloat(b.GetUpperBound(), 'g', -1, 64)})
+				}
+				if !infSeen {
+					add("_bucket", float64(m.GetHistogram().GetSampleCount()), remoteWriteLabel{"le", "+Inf"})
+				}
+				add("_sum", m.GetHistogram().GetSampleSum())
+				add("_count", float64(m.GetHistogram().GetSampleCount()))
+			}
+		}
+	}
+	return buf
+}
+
+// encodeTimeSeries encodes a prometheus.TimeSeries message with a single
+// sample. Remote write requires the labels to be sorted by name.
+func encodeTimeSeries(name string, labels, extra []remoteWriteLabel, value float64, ts int64) []byte {
+	all := make([]remoteWriteLabel, 0, len(labels)+len(extra)+1)
+	all = append(all, remoteWriteLabel{"__name__", name})
+	all = append(all, labels...)
+	all = append(all, extra...)
+	sort.Slice(all, func(i, j int) bool { return all[i].name < all[j].name })
+
+	var buf []byte
+	for _, l := range all {
+		var label []byte
+		label = protowire.AppendTag(label, 1, protowire.BytesType)
+		label = protowire.AppendString(label, l.name)
+		label = protowire.AppendTag(label, 2, protowire.BytesType)
+		label = protowire.AppendString(label, l.value)
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, label)
+	}
+
+	var sample []byte
+	sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+	sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+	sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+	sample = protowire.AppendVarint(sample, uint64(ts))
+	buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+	buf = protowire.AppendBytes(buf, sample)
+	return buf
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodedSeries is a flattened prometheus.TimeSeries with a single sample.
+type decodedSeries struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+func consumeFields(t *testing.T, b []byte, f func(num protowire.Number, typ protowire.Type, b []byte) int) {
+	t.Helper()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatal(protowire.ParseError(n))
+		}
+		b = b[n:]
+		n = f(num, typ, b)
+		if n < 0 {
+			t.Fatal(protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+}
+
+func decodeWriteRequest(t *testing.T, b []byte) []decodedSeries {
+	var series []decodedSeries
+	consumeFields(t, b, func(_ protowire.Number, _ protowire.Type, b []byte) int {
+		ts, n := protowire.ConsumeBytes(b)
+		s := decodedSeries{labels: map[string]string{}}
+		consumeFields(t, ts, func(num protowire.Number, _ protowire.Type, b []byte) int {
+			msg, n := protowire.ConsumeBytes(b)
+			switch num {
+			case 1:
+				var name, value string
+				consumeFields(t, msg, func(num protowire.Number, _ protowire.Type, b []byte) int {
+					v, n := protowire.ConsumeString(b)
+					if num == 1 {
+						name = v
+					} else {
+						value = v
+					}
+					return n
+				})
+				s.labels[name] = value
+			case 2:
+				consumeFields(t, msg, func(num protowire.Number, typ protowire.Type, b []byte) int {
+					if num == 1 {
+						v, n := protowire.ConsumeFixed64(b)
+						s.value = math.Float64frombits(v)
+						return n
+					}
+					v, n := protowire.ConsumeVarint(b)
+					s.timestamp = int64(v)
+					return n
+				})
+			}
+			return n
+		})
+		series = append(series, s)
+		return n
+	})
+	return series
+}
+
+func TestEncodeWriteRequest(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_total", Help: "help"}, []string{"mode"})
+	counter.WithLabelValues("user").Add(3)
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_seconds", Help: "help", Buckets: []float64{1}})
+	histogram.Observe(0.5)
+	reg.MustRegister(counter, histogram)
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.UnixMilli(1700000000000)
+	series := decodeWriteRequest(t, encodeWriteRequest(mfs, now))
+
+	want := map[string]float64{
+		"test_seconds_bucket{le=1}":    1,
+		"test_seconds_bucket{le=+Inf}": 1,
+		"test_seconds_sum":             0.5,
+		"test_seconds_count":           1,
+		"test_total{mode=user}":        3,
+	}
+	if len(series) != len(want) {
+		t.Fatalf("want %d series, got %d: %v", len(want), len(series), series)
+	}
+	for _, s := range series {
+		key := s.labels["__name__"]
+		if le, ok := s.labels["le"]; ok {
+			key += "{le=" + le + "}"
+		}
+		if mode, ok := s.labels["mode"]; ok {
+			key += "{mode=" + mode + "}"
+		}
+		if v, ok := want[key]; !ok || v != s.value {
+			t.Errorf("unexpected series %s with value %v", key, s.value)
+		}
+		if s.timestamp != now.UnixMilli() {
+			t.Errorf("want timestamp %d, got %d", now.UnixMilli(), s.timestamp)
+		}
+	}
+}
+
+func TestRemoteWriterPush(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first request to exercise the retry path.
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		if got := r.Header.Get("Content-Encoding"); got != "snappy" {
+			t.Errorf("unexpected Content-Encoding header %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if _, err := snappy.Decode(nil, body); err != nil {
+			t.Errorf("body is not snappy encoded: %s", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "test", Help: "help"}))
+	w, err := newRemoteWriter(server.URL, time.Second, "", reg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.bearerToken = "secret"
+
+	if err := w.push(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("want 2 requests, got %d", got)
+	}
+
+	// Client errors are not retried.
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	requests.Store(0)
+	if err := w.push(context.Background()); err == nil {
+		t.Error("expected error for bad request")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("want 1 request, got %d", got)
+	}
+}
+
+func TestRemoteWriterRunStopsOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	w, err := newRemoteWriter(server.URL, time.Hour, "", prometheus.NewRegistry(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.run(ctx)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("remote writer didn't stop after its context was canceled")
+	}
+}
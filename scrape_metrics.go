@@ -0,0 +1,62 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// scrapeMetrics tracks the /metrics requests served by the handler, including
+// the filtered and JSON ones, to tell whether slow scrapes overlap.
+type scrapeMetrics struct {
+	inFlight atomic.Int64
+	duration prometheus.Histogram
+}
+
+func newScrapeMetrics() *scrapeMetrics {
+	return &scrapeMetrics{
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "node_exporter",
+			Name:      "scrape_duration_seconds",
+			Help:      "Duration of /metrics requests, from the start of the request until the response was written.",
+			Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}),
+	}
+}
+
+// collectors returns the metrics to register with the exporter metrics.
+func (m *scrapeMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "node_exporter",
+			Name:      "scrapes_in_flight",
+			Help:      "Number of /metrics requests currently being served.",
+		}, func() float64 { return float64(m.inFlight.Load()) }),
+		m.duration,
+	}
+}
+
+// start records the start of a scrape, the returned function must be called
+// once it finished.
+func (m *scrapeMetrics) start() func() {
+	begin := time.Now()
+	m.inFlight.Add(1)
+	return func() {
+		m.inFlight.Add(-1)
+		m.duration.Observe(time.Since(begin).Seconds())
+	}
+}
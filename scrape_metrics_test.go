@@ -0,0 +1,69 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestScrapeMetrics(t *testing.T) {
+	m := newScrapeMetrics()
+	collectors := m.collectors()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors...)
+	inFlight := collectors[0]
+
+	var started, wg sync.WaitGroup
+	release := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		started.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			done := m.start()
+			started.Done()
+			<-release
+			done()
+		}()
+	}
+	started.Wait()
+
+	if got := testutil.ToFloat64(inFlight); got != 8 {
+		t.Errorf("want 8 scrapes in flight, got %v", got)
+	}
+
+	close(release)
+	wg.Wait()
+	if got := testutil.ToFloat64(inFlight); got != 0 {
+		t.Errorf("want no scrapes in flight, got %v", got)
+	}
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mf := range families {
+		if mf.GetName() != "node_exporter_scrape_duration_seconds" {
+			continue
+		}
+		if got := mf.Metric[0].Histogram.GetSampleCount(); got != 8 {
+			t.Errorf("want 8 observed scrapes, got %d", got)
+		}
+		return
+	}
+	t.Error("want node_exporter_scrape_duration_seconds")
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/node_exporter/collector"
+)
+
+// runSelfTest creates and runs every enabled collector once, like a scrape
+// would, and writes a pass/fail line per collector to w. It returns false if
+// any collector couldn't be created or failed.
+func runSelfTest(w io.Writer, logger *slog.Logger) bool {
+	var ok, noData, failed int
+
+	// Create the collectors one by one, NewNodeCollector stops at the first
+	// factory error without telling which collector it was.
+	var created []string
+	for _, s := range collector.CollectorStatuses() {
+		if !s.Enabled {
+			continue
+		}
+		if _, err := collector.NewNodeCollector(logger, s.Name); err != nil {
+			fmt.Fprintf(w, "FAIL    %s: %s\n", s.Name, err)
+			failed++
+			continue
+		}
+		created = append(created, s.Name)
+	}
+
+	if len(created) > 0 {
+		nc, err := collector.NewNodeCollector(logger, created...)
+		if err != nil {
+			fmt.Fprintf(w, "FAIL    %s\n", err)
+			return false
+		}
+		r := prometheus.NewRegistry()
+		if err := r.Register(nc); err != nil {
+			fmt.Fprintf(w, "FAIL    couldn't register node collector: %s\n", err)
+			return false
+		}
+		// Errors of single collectors are recorded in their status, an error
+		// here means the collectors exposed inconsistent metrics.
+		if _, err := r.Gather(); err != nil {
+			fmt.Fprintf(w, "FAIL    gathering metrics: %s\n", err)
+			failed++
+		}
+	}
+
+	ran := make(map[string]bool, len(created))
+	for _, name := range created {
+		ran[name] = true
+	}
+	for _, s := range collector.CollectorStatuses() {
+		switch {
+		case !ran[s.Name]:
+		case s.LastError == collector.ErrNoData.Error():
+			fmt.Fprintf(w, "NO DATA %s\n", s.Name)
+			noData++
+		case s.LastError != "":
+			fmt.Fprintf(w, "FAIL    %s: %s\n", s.Name, s.LastError)
+			failed++
+		default:
+			fmt.Fprintf(w, "OK      %s\n", s.Name)
+			ok++
+		}
+	}
+
+	fmt.Fprintf(w, "%d collectors ok, %d without data, %d failed\n", ok, noData, failed)
+	return failed == 0
+}
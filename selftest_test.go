@@ -0,0 +1,54 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/node_exporter/collector"
+)
+
+func TestRunSelfTest(t *testing.T) {
+	// An empty procfs: loadavg fails, entropy has no data and time doesn't
+	// read procfs at all.
+	if _, err := kingpin.CommandLine.Parse([]string{
+		"--path.procfs", t.TempDir(),
+		"--collector.loadavg",
+		"--collector.entropy",
+		"--collector.time",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	collector.DisableDefaultCollectors()
+
+	var out bytes.Buffer
+	if runSelfTest(&out, slog.New(slog.NewTextHandler(io.Discard, nil))) {
+		t.Error("expected the self-test to fail")
+	}
+	for _, line := range []string{
+		"NO DATA entropy\n",
+		"FAIL    loadavg: couldn't get load: ",
+		"OK      time\n",
+		"1 collectors ok, 1 without data, 1 failed\n",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("expected %q in output:\n%s", line, out.String())
+		}
+	}
+}